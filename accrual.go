@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Longest tick accepted in real-time mode, so a stall doesn't turn into a burst of production
+const maxTickDelta = 0.25
+
+// Longest step animations take, so effects don't jump after the window was
+// unfocused or the game stalled
+const maxAnimationDelta = 0.1
+
+// accrualMode selects how much game time a single Update covers. Both modes feed
+// the same production and rotation logic, they only differ in the delta they pass on.
+//
+// Real-time advances by the wall-clock time since the previous Update. Production
+// stays accurate when Ebiten drops ticks or runs uncapped, at the cost of small
+// run-to-run jitter. This is what players get by default.
+//
+// Fixed-tick advances exactly 1/TPS per Update. The same number of ticks always
+// produces the same state, which makes it the mode for tests, but the game falls
+// behind the wall clock whenever ticks are dropped.
+type accrualMode int
+
+const (
+	accrualRealTime accrualMode = iota
+	accrualFixedTick
+)
+
+func (m accrualMode) String() string {
+	if m == accrualFixedTick {
+		return "fixed-tick"
+	}
+	return "real-time"
+}
+
+func (m accrualMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *accrualMode) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "real-time":
+		*m = accrualRealTime
+	case "fixed-tick":
+		*m = accrualFixedTick
+	default:
+		return fmt.Errorf("unknown accrual mode %q (want real-time or fixed-tick)", b)
+	}
+	return nil
+}
+
+// WithAccrualMode overrides the accrual mode from the settings, e.g. fixed-tick in tests
+func WithAccrualMode(m accrualMode) GameOption {
+	return func(g *Game) {
+		g.settings.Accrual = m
+	}
+}
+
+// Nominal tick length for the configured tick rate. Uncapped ticks follow the
+// frame rate, so they use the measured rate once Ebiten has one.
+func nominalTickDelta(tps int, actualTPS float64) float64 {
+	if tps != ebiten.SyncWithFPS {
+		return 1 / float64(tps)
+	}
+	if actualTPS > 0 {
+		return 1 / actualTPS
+	}
+	return 1.0 / 60.0 // Before the first measurement
+}
+
+// Seconds of game time covered by the current tick
+func (g *Game) tickDelta() float64 {
+	now := time.Now()
+	last := g.lastTick
+	g.lastTick = now
+
+	fixed := nominalTickDelta(ebiten.TPS(), ebiten.ActualTPS())
+
+	if g.settings.Accrual == accrualFixedTick || last.IsZero() {
+		return fixed
+	}
+	// With pause on blur a long gap means the window was in the background,
+	// resume with a normal tick instead of crediting the gap
+	if g.settings.PauseOnBlur && now.Sub(last).Seconds() > maxTickDelta {
+		return fixed
+	}
+	return math.Min(now.Sub(last).Seconds(), maxTickDelta)
+}
+
+// Wall-clock seconds since the previous Update, for animations. Unlike tickDelta
+// it ignores the accrual mode and fast forward, so effects run at the same
+// real-world speed at any tick rate.
+func (g *Game) animationDelta() float64 {
+	now := time.Now()
+	last := g.lastAnimationTick
+	g.lastAnimationTick = now
+
+	if last.IsZero() {
+		return nominalTickDelta(ebiten.TPS(), ebiten.ActualTPS())
+	}
+	return math.Min(now.Sub(last).Seconds(), maxAnimationDelta)
+}
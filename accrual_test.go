@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestNominalTickDelta(t *testing.T) {
+	tests := []struct {
+		tps       int
+		actualTPS float64
+		want      float64
+	}{
+		{60, 0, 1.0 / 60},
+		{30, 58, 1.0 / 30},
+		{120, 0, 1.0 / 120},
+		{ebiten.SyncWithFPS, 144, 1.0 / 144},
+		{ebiten.SyncWithFPS, 0, 1.0 / 60},
+	}
+	for _, tt := range tests {
+		if got := nominalTickDelta(tt.tps, tt.actualTPS); got != tt.want {
+			t.Errorf("nominalTickDelta(%d, %v) = %v, want %v", tt.tps, tt.actualTPS, got, tt.want)
+		}
+	}
+}
+
+func TestTickDelta(t *testing.T) {
+	fixed := nominalTickDelta(ebiten.TPS(), ebiten.ActualTPS())
+	tests := []struct {
+		name        string
+		mode        accrualMode
+		pauseOnBlur bool
+		gap         time.Duration // Since the previous tick, 0 for the first tick
+		want        float64
+	}{
+		{"first tick", accrualRealTime, false, 0, fixed},
+		{"real-time", accrualRealTime, false, 100 * time.Millisecond, 0.1},
+		{"real-time stall is clamped", accrualRealTime, false, 5 * time.Second, maxTickDelta},
+		{"fixed-tick ignores the clock", accrualFixedTick, false, 100 * time.Millisecond, fixed},
+		{"fixed-tick after a stall", accrualFixedTick, false, 5 * time.Second, fixed},
+		{"background gap with pause on blur", accrualRealTime, true, 5 * time.Second, fixed},
+		{"short gap with pause on blur", accrualRealTime, true, 100 * time.Millisecond, 0.1},
+	}
+	for _, tt := range tests {
+		g := newTestGame(t, WithAccrualMode(tt.mode))
+		g.settings.PauseOnBlur = tt.pauseOnBlur
+		g.lastTick = time.Time{}
+		if tt.gap > 0 {
+			g.lastTick = time.Now().Add(-tt.gap)
+		}
+		// The real clock moves on a little while the test runs
+		if got := g.tickDelta(); math.Abs(got-tt.want) > 0.02 {
+			t.Errorf("%s: tickDelta() = %v, want %v", tt.name, got, tt.want)
+		}
+		if g.lastTick.IsZero() {
+			t.Errorf("%s: tick time wasn't recorded", tt.name)
+		}
+	}
+}
+
+func TestAccrualModesProduceTheSame(t *testing.T) {
+	// Both modes feed their delta into the same step, so equal game time pays the same
+	var mana [2]float64
+	for i, mode := range []accrualMode{accrualRealTime, accrualFixedTick} {
+		g := newIdleTestGame(t)
+		g.settings.Accrual = mode
+		for range 120 {
+			g.step(1.0 / 60)
+		}
+		mana[i] = g.manaValue()
+	}
+	if mana[0] != mana[1] || mana[0] == 0 {
+		t.Errorf("real-time produced %v, fixed-tick %v", mana[0], mana[1])
+	}
+}
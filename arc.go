@@ -0,0 +1,78 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// A single white pixel, the source of solid-colored triangles
+var whitePixel = func() *ebiten.Image {
+	img := ebiten.NewImage(3, 3)
+	img.Fill(color.White)
+	return img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+}()
+
+// Path along a circle from startAngle to endAngle (radians, clockwise on screen),
+// split into segments straight lines. A full turn or more is closed.
+func arcPath(centerX, centerY, radius, startAngle, endAngle float32, segments int) *vector.Path {
+	var path vector.Path
+	for i := 0; i <= segments; i++ {
+		angle := float64(startAngle + (endAngle-startAngle)*float32(i)/float32(segments))
+		x := centerX + radius*float32(math.Cos(angle))
+		y := centerY + radius*float32(math.Sin(angle))
+		if i == 0 {
+			path.MoveTo(x, y)
+		} else {
+			path.LineTo(x, y)
+		}
+	}
+	if endAngle-startAngle >= 2*math.Pi {
+		path.Close()
+	}
+	return &path
+}
+
+// Stroke an arc of the circle around centerX, centerY with round ends. A full
+// circle uses arcSegments lines, shorter arcs proportionally fewer.
+func (g *Game) drawArcSegment(screen *ebiten.Image, centerX, centerY, radius, thickness, startAngle, endAngle float32, col color.RGBA) {
+	vs, is := g.arcTriangles(centerX, centerY, radius, thickness, startAngle, endAngle, col)
+	if len(is) == 0 {
+		return
+	}
+	screen.DrawTriangles(vs, is, whitePixel, &ebiten.DrawTrianglesOptions{
+		ColorScaleMode: ebiten.ColorScaleModePremultipliedAlpha,
+		AntiAlias:      g.antialias(),
+	})
+}
+
+// Triangles of the stroked arc drawArcSegment draws, in reused buffers. Tests
+// check the geometry here instead of comparing rendered images: reading pixels
+// back needs a graphics driver and a running game loop, which headless test
+// runs don't have.
+func (g *Game) arcTriangles(centerX, centerY, radius, thickness, startAngle, endAngle float32, col color.RGBA) ([]ebiten.Vertex, []uint16) {
+	sweep := min(endAngle-startAngle, 2*math.Pi)
+	if sweep <= 0 {
+		return nil, nil
+	}
+	segments := max(1, int(math.Ceil(float64(g.arcSegments())*float64(sweep)/(2*math.Pi))))
+	path := arcPath(centerX, centerY, radius, startAngle, startAngle+sweep, segments)
+
+	op := &vector.StrokeOptions{Width: thickness, LineCap: vector.LineCapRound, LineJoin: vector.LineJoinRound}
+	vs, is := path.AppendVerticesAndIndicesForStroke(g.arcVertices[:0], g.arcIndices[:0], op)
+	g.arcVertices, g.arcIndices = vs, is
+
+	// Same color handling as the vector package's own stroke helpers
+	r, gr, b, a := col.RGBA()
+	for i := range vs {
+		vs[i].SrcX, vs[i].SrcY = 1, 1
+		vs[i].ColorR = float32(r) / 0xffff
+		vs[i].ColorG = float32(gr) / 0xffff
+		vs[i].ColorB = float32(b) / 0xffff
+		vs[i].ColorA = float32(a) / 0xffff
+	}
+	return vs, is
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestArcTriangles(t *testing.T) {
+	const (
+		cx, cy    = 200, 150
+		radius    = 80
+		thickness = 10
+	)
+	tests := []struct {
+		name       string
+		start, end float32
+	}{
+		{"quarter", 0, math.Pi / 2},
+		{"half from the top", -math.Pi / 2, math.Pi / 2},
+		{"full circle", 0, 2 * math.Pi},
+		{"more than a full circle", 0, 3 * math.Pi},
+		{"tiny", 1, 1.01},
+	}
+	col := color.RGBA{255, 128, 0, 255}
+	for _, tt := range tests {
+		g := newTestGame(t)
+		vs, is := g.arcTriangles(cx, cy, radius, thickness, tt.start, tt.end, col)
+		if len(is) == 0 || len(is)%3 != 0 {
+			t.Fatalf("%s: %d indices", tt.name, len(is))
+		}
+		for _, idx := range is {
+			if int(idx) >= len(vs) {
+				t.Fatalf("%s: index %d out of %d vertices", tt.name, idx, len(vs))
+			}
+		}
+
+		// Every vertex lies on the stroke: within half the thickness of the
+		// circle, and the round caps reach at most that far past the ends
+		sweep := min(tt.end-tt.start, 2*math.Pi)
+		margin := math.Asin(thickness/2.0/radius) + 0.01
+		for _, v := range vs {
+			dx, dy := float64(v.DstX-cx), float64(v.DstY-cy)
+			if d := math.Hypot(dx, dy); d < radius-thickness/2-0.5 || d > radius+thickness/2+0.5 {
+				t.Errorf("%s: vertex %v,%v is %v from the center", tt.name, v.DstX, v.DstY, d)
+			}
+			if sweep < 2*math.Pi {
+				// Angle from the start, counting the start cap as slightly negative
+				angle := math.Mod(math.Atan2(dy, dx)-float64(tt.start)+margin+4*math.Pi, 2*math.Pi) - margin
+				if angle > float64(sweep)+margin {
+					t.Errorf("%s: vertex %v,%v at %v rad outside the arc", tt.name, v.DstX, v.DstY, angle)
+				}
+			}
+			if v.ColorR != 1 || v.ColorA != 1 || v.SrcX != 1 {
+				t.Errorf("%s: vertex isn't a solid white-pixel sample of the color", tt.name)
+			}
+		}
+	}
+}
+
+func TestArcTrianglesEmpty(t *testing.T) {
+	g := newTestGame(t)
+	for _, sweep := range []float32{0, -1} {
+		if vs, is := g.arcTriangles(0, 0, 10, 2, 1, 1+sweep, color.RGBA{}); len(vs) != 0 || len(is) != 0 {
+			t.Errorf("sweep %v: got %d vertices", sweep, len(vs))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const benchmarkDuration = 5.0 // Seconds a stress test runs
+
+// benchmark is a debug stress test that maxes every generator for a few seconds
+// and measures frame times, then puts the game back the way it was
+type benchmark struct {
+	running   bool
+	elapsed   float64       // Game seconds since the start
+	frames    int           // Frames measured
+	total     time.Duration // Sum of frame times
+	worst     time.Duration // Longest frame
+	lastFrame time.Time
+	saved     saveData // State restored when the test ends
+	result    string   // Summary of the last run
+}
+
+// Start a stress test unless one is already running
+func (g *Game) startBenchmark() {
+	if g.bench.running {
+		return
+	}
+	g.bench = benchmark{running: true, saved: g.snapshot()}
+	g.logEvent("started benchmark")
+
+	for i := range g.generators {
+		g.generators[i].level = maxGeneratorLevel
+		g.generators[i].retired = false
+	}
+	g.calculateManaPerSec()
+}
+
+// Advance a running stress test and restore the game when it's done
+func (g *Game) updateBenchmark(dt float64) {
+	if !g.bench.running {
+		return
+	}
+	g.bench.elapsed += dt
+	if g.bench.elapsed < benchmarkDuration {
+		return
+	}
+
+	g.bench.running = false
+	if err := g.restore(g.bench.saved); err != nil {
+		log.Printf("failed to restore state after benchmark: %v", err)
+	}
+	if g.bench.frames == 0 {
+		g.bench.result = "Benchmark: no frames measured"
+		return
+	}
+	avg := g.bench.total / time.Duration(g.bench.frames)
+	g.bench.result = fmt.Sprintf("Benchmark: %d frames, avg %.2fms, worst %.2fms",
+		g.bench.frames, float64(avg.Microseconds())/1000, float64(g.bench.worst.Microseconds())/1000)
+	log.Print(g.bench.result)
+}
+
+// Measure the time since the previous frame while a stress test runs
+func (g *Game) recordBenchmarkFrame() {
+	if !g.bench.running {
+		return
+	}
+	now := time.Now()
+	if !g.bench.lastFrame.IsZero() {
+		frame := now.Sub(g.bench.lastFrame)
+		g.bench.frames++
+		g.bench.total += frame
+		g.bench.worst = max(g.bench.worst, frame)
+	}
+	g.bench.lastFrame = now
+}
+
+func (g *Game) drawBenchmark(screen *ebiten.Image) {
+	status := g.bench.result
+	if g.bench.running {
+		status = fmt.Sprintf("Benchmark running... %.0fs left", benchmarkDuration-g.bench.elapsed)
+	}
+	if status == "" {
+		return
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(tokenShopX, 120)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 150, 150, 255})
+	text.Draw(screen, status, g.face(18), op)
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Digits of exponent difference past which the smaller addend no longer changes
+// the float64 mantissa of the larger one
+const bigNumberAddDigits = 17
+
+// BigNumber is mantissa × 10^exp, normalized so 1 <= |mantissa| < 10, or zero.
+// It has float64's precision but an exponent far past float64's range, without
+// the allocations of math/big.
+type BigNumber struct {
+	mantissa float64
+	exp      int
+}
+
+// NewBigNumber converts v. NaN becomes zero and infinities the largest float64,
+// as elsewhere in the economy.
+func NewBigNumber(v float64) BigNumber {
+	return BigNumber{mantissa: clampFinite(v, 0)}.normalize()
+}
+
+// Move the mantissa back into [1, 10), adjusting the exponent
+func (b BigNumber) normalize() BigNumber {
+	if b.mantissa == 0 || !isFinite(b.mantissa) {
+		return BigNumber{}
+	}
+	e := int(math.Floor(math.Log10(math.Abs(b.mantissa))))
+	b.mantissa /= math.Pow10(e)
+	b.exp += e
+	// Log10 can be off by one right at a power of ten
+	switch abs := math.Abs(b.mantissa); {
+	case abs >= 10:
+		b.mantissa /= 10
+		b.exp++
+	case abs < 1:
+		b.mantissa *= 10
+		b.exp--
+	}
+	return b
+}
+
+// Add returns b + o
+func (b BigNumber) Add(o BigNumber) BigNumber {
+	if b.mantissa == 0 {
+		return o
+	}
+	if o.mantissa == 0 {
+		return b
+	}
+	if b.exp < o.exp {
+		b, o = o, b
+	}
+	diff := b.exp - o.exp
+	if diff > bigNumberAddDigits {
+		return b
+	}
+	return BigNumber{mantissa: b.mantissa + o.mantissa/math.Pow10(diff), exp: b.exp}.normalize()
+}
+
+// Mul returns b × o
+func (b BigNumber) Mul(o BigNumber) BigNumber {
+	return BigNumber{mantissa: b.mantissa * o.mantissa, exp: b.exp + o.exp}.normalize()
+}
+
+// Neg returns -b
+func (b BigNumber) Neg() BigNumber {
+	b.mantissa = -b.mantissa
+	return b
+}
+
+// Pow returns b^n for n >= 0
+func (b BigNumber) Pow(n int) BigNumber {
+	result := NewBigNumber(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = result.Mul(b)
+		}
+		b = b.Mul(b)
+	}
+	return result
+}
+
+// Log returns the natural logarithm of b, which fits a float64 even when b
+// doesn't. It is NaN for b <= 0, like math.Log.
+func (b BigNumber) Log() float64 {
+	if b.mantissa == 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(b.mantissa) + float64(b.exp)*math.Ln10
+}
+
+// Cmp compares b and o: -1 if b < o, 0 if equal, +1 if b > o
+func (b BigNumber) Cmp(o BigNumber) int {
+	sign := b.sign()
+	if c := cmp.Compare(sign, o.sign()); c != 0 || sign == 0 {
+		return c
+	}
+	// A larger exponent means a larger magnitude, which is smaller when negative
+	if b.exp != o.exp {
+		return cmp.Compare(b.exp, o.exp) * sign
+	}
+	return cmp.Compare(b.mantissa, o.mantissa)
+}
+
+func (b BigNumber) sign() int {
+	return cmp.Compare(b.mantissa, 0)
+}
+
+// Float64 returns the closest float64, ±Inf past its range
+func (b BigNumber) Float64() float64 {
+	return b.mantissa * math.Pow10(b.exp)
+}
+
+// MarshalJSON writes b as a decimal string such as "1.5e400", since JSON
+// numbers are read back as float64 by most decoders
+func (b BigNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatFloat(b.mantissa, 'g', -1, 64) + "e" + strconv.Itoa(b.exp))
+}
+
+// UnmarshalJSON reads a decimal string written by MarshalJSON or a plain JSON
+// number, as in saves from before mana was a BigNumber
+func (b *BigNumber) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := parseBigNumber(s)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// Parse decimal notation like "123.4" or "1.234e400"
+func parseBigNumber(s string) (BigNumber, error) {
+	mantissa, exp := s, 0
+	if i := strings.LastIndexAny(s, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.Atoi(s[i+1:]); err != nil {
+			return BigNumber{}, fmt.Errorf("invalid number %q", s)
+		}
+		mantissa = s[:i]
+	}
+	m, err := strconv.ParseFloat(mantissa, 64)
+	if err != nil || !isFinite(m) {
+		return BigNumber{}, fmt.Errorf("invalid number %q", s)
+	}
+	return BigNumber{mantissa: m, exp: exp}.normalize(), nil
+}
+
+// String formats like formatNumber: two decimals below 1000, three significant
+// figures with a suffix (1.23K, 45.6M) while there is one, then 1.23e45. Unlike
+// formatNumber it keeps going past float64's range.
+func (b BigNumber) String() string {
+	if b.exp < 2 || b.exp == 2 && math.Abs(b.mantissa) < 9.99995 {
+		return fmt.Sprintf("%.2f", b.Float64())
+	}
+
+	// Round to three significant figures first so 999,999 becomes 1.00M, not 1000K
+	m, exp := math.Round(b.mantissa*100)/100, b.exp
+	if math.Abs(m) >= 10 {
+		m /= 10
+		exp++
+	}
+
+	tier := exp / 3
+	if tier > len(numberSuffixes) {
+		return fmt.Sprintf("%.2fe%d", m, exp)
+	}
+	scaled := m * math.Pow10(exp%3)
+	switch exp % 3 {
+	case 0:
+		return fmt.Sprintf("%.2f%s", scaled, numberSuffixes[tier-1])
+	case 1:
+		return fmt.Sprintf("%.1f%s", scaled, numberSuffixes[tier-1])
+	}
+	return fmt.Sprintf("%.0f%s", scaled, numberSuffixes[tier-1])
+}
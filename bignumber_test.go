@@ -0,0 +1,111 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBigNumberNormalize(t *testing.T) {
+	tests := []struct {
+		in       float64
+		mantissa float64
+		exp      int
+	}{
+		{0, 0, 0},
+		{1, 1, 0},
+		{9.5, 9.5, 0},
+		{10, 1, 1},
+		{0.5, 5, -1},
+		{1000, 1, 3},
+		{-2500, -2.5, 3},
+		{1e300, 1, 300},
+		{math.NaN(), 0, 0},
+	}
+	for _, tt := range tests {
+		b := NewBigNumber(tt.in)
+		if math.Abs(b.mantissa-tt.mantissa) > 1e-12 || b.exp != tt.exp {
+			t.Errorf("NewBigNumber(%v) = %v×10^%d, want %v×10^%d", tt.in, b.mantissa, b.exp, tt.mantissa, tt.exp)
+		}
+		if m := math.Abs(b.mantissa); m != 0 && (m < 1 || m >= 10) {
+			t.Errorf("NewBigNumber(%v) mantissa %v out of [1, 10)", tt.in, b.mantissa)
+		}
+	}
+}
+
+func TestBigNumberAddMul(t *testing.T) {
+	big := BigNumber{mantissa: 1, exp: 400}
+	tests := []struct {
+		name      string
+		got, want BigNumber
+	}{
+		{"add same exponent", NewBigNumber(2).Add(NewBigNumber(3)), NewBigNumber(5)},
+		{"add carries", NewBigNumber(6).Add(NewBigNumber(7)), NewBigNumber(13)},
+		{"add small gap", NewBigNumber(1000).Add(NewBigNumber(1)), NewBigNumber(1001)},
+		{"add beyond precision", big.Add(NewBigNumber(1)), big},
+		{"add to zero", BigNumber{}.Add(big), big},
+		{"add cancels", NewBigNumber(5).Add(NewBigNumber(-5)), BigNumber{}},
+		{"add past float64", big.Add(big), BigNumber{mantissa: 2, exp: 400}},
+		{"mul", NewBigNumber(20).Mul(NewBigNumber(30)), NewBigNumber(600)},
+		{"mul past float64", NewBigNumber(1e200).Mul(NewBigNumber(5e200)), BigNumber{mantissa: 5, exp: 400}},
+		{"mul by zero", big.Mul(BigNumber{}), BigNumber{}},
+		{"mul tiny", big.Mul(NewBigNumber(1e-300)), NewBigNumber(1e100)},
+	}
+	for _, tt := range tests {
+		if tt.got.exp != tt.want.exp || math.Abs(tt.got.mantissa-tt.want.mantissa) > 1e-9 {
+			t.Errorf("%s: got %v×10^%d, want %v×10^%d", tt.name, tt.got.mantissa, tt.got.exp, tt.want.mantissa, tt.want.exp)
+		}
+	}
+}
+
+func TestBigNumberCmp(t *testing.T) {
+	// Ascending order
+	ordered := []BigNumber{
+		{mantissa: -1, exp: 400},
+		NewBigNumber(-1000),
+		NewBigNumber(-2),
+		{},
+		NewBigNumber(0.5),
+		NewBigNumber(2),
+		NewBigNumber(3),
+		NewBigNumber(1000),
+		NewBigNumber(math.MaxFloat64),
+		{mantissa: 1, exp: 400},
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := a.Cmp(b); got != want {
+				t.Errorf("%v.Cmp(%v) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestBigNumberString(t *testing.T) {
+	tests := []struct {
+		in   BigNumber
+		want string
+	}{
+		{NewBigNumber(0), "0.00"},
+		{NewBigNumber(999.99), "999.99"},
+		{NewBigNumber(999.999), "1.00K"},
+		{NewBigNumber(1000), "1.00K"},
+		{NewBigNumber(12345), "12.3K"},
+		{NewBigNumber(999_999), "1.00M"},
+		{NewBigNumber(1e33), "1.00Dc"},
+		{NewBigNumber(999e33), "999Dc"},
+		{NewBigNumber(1e36), "1.00e36"},
+		{BigNumber{mantissa: 1.5, exp: 400}, "1.50e400"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("String(%v×10^%d) = %q, want %q", tt.in.mantissa, tt.in.exp, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	boostMultiplier = 2.0   // Production factor while the boost is active
+	boostDuration   = 10.0  // Seconds the boost lasts
+	boostCooldown   = 120.0 // Seconds before the boost can be used again, counted from activation
+)
+
+// Boost UI layout (bottom center)
+const (
+	boostBarWidth  = 300
+	boostBarHeight = 16
+	boostBarBottom = 60 // Distance of the bar's top from the bottom edge
+)
+
+// Trigger the "boost all" ultimate if it's charged
+func (g *Game) activateBoost() bool {
+	if g.economyFrozen() || g.boostCooldown > 0 {
+		return false
+	}
+	g.boostRemaining = boostDuration
+	g.boostCooldown = boostCooldown
+	g.logEvent("activated boost")
+	g.calculateManaPerSec()
+	return true
+}
+
+// Count down the boost and its cooldown
+func (g *Game) updateBoost(dt float64) {
+	g.boostCooldown = max(0, g.boostCooldown-dt)
+	if g.boostRemaining > 0 {
+		g.boostRemaining = max(0, g.boostRemaining-dt)
+		if g.boostRemaining == 0 {
+			// Drop back to the normal rate right away
+			g.calculateManaPerSec()
+		}
+	}
+}
+
+// Global production factor from the boost
+func (g *Game) boostProductionMultiplier() float64 {
+	if g.boostRemaining > 0 {
+		return boostMultiplier
+	}
+	return 1
+}
+
+func (g *Game) drawBoost(screen *ebiten.Image) {
+	x := float32(g.width/2 - boostBarWidth/2)
+	boostBarY := float32(g.height - boostBarBottom)
+
+	var label string
+	var fill float32
+	barColor := color.RGBA{100, 200, 255, 255}
+	switch {
+	case g.boostRemaining > 0:
+		label = fmt.Sprintf("Boost active: x%.0f for %.0fs", boostMultiplier, g.boostRemaining)
+		fill = float32(g.boostRemaining / boostDuration)
+		barColor = color.RGBA{255, 215, 100, 255}
+	case g.boostCooldown > 0:
+		label = fmt.Sprintf("Boost recharging: %.0fs", g.boostCooldown)
+		fill = float32(1 - g.boostCooldown/boostCooldown)
+	default:
+		label = "Boost ready - press B"
+		fill = 1
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(boostBarY)-30)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, label, g.face(20), op)
+
+	vector.DrawFilledRect(screen, x, boostBarY, boostBarWidth, boostBarHeight, color.RGBA{50, 50, 70, 255}, false)
+	vector.DrawFilledRect(screen, x, boostBarY, boostBarWidth*fill, boostBarHeight, barColor, false)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoostDoublesProduction(t *testing.T) {
+	g := newIdleTestGame(t)
+	base := g.totalMultiplier
+	if !g.activateBoost() {
+		t.Fatal("activateBoost() failed")
+	}
+	if got, want := g.totalMultiplier, base*boostMultiplier; math.Abs(got-want) > 1e-12 {
+		t.Errorf("boosted rate = %v, want %v", got, want)
+	}
+
+	// The boost runs out after its duration and production drops back
+	for range int(boostDuration*10) + 1 {
+		g.step(0.1)
+	}
+	if g.boostRemaining != 0 || math.Abs(g.totalMultiplier-base) > 1e-12 {
+		t.Errorf("after the boost: %vs left, rate %v, want %v", g.boostRemaining, g.totalMultiplier, base)
+	}
+}
+
+func TestBoostCooldown(t *testing.T) {
+	tests := []struct {
+		name     string
+		elapsed  float64 // Seconds after the first activation
+		activate bool
+	}{
+		{"while active", 1, false},
+		{"after the boost ended", boostDuration + 1, false},
+		{"just before recharged", boostCooldown - 1, false},
+		{"recharged", boostCooldown, true},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.activateBoost()
+		for elapsed := 0.0; elapsed < tt.elapsed-1e-9; elapsed += 0.5 {
+			g.step(0.5)
+		}
+		if got := g.activateBoost(); got != tt.activate {
+			t.Errorf("%s: activateBoost() = %v, want %v", tt.name, got, tt.activate)
+		}
+	}
+
+	// A paused economy can't trigger it either
+	g := newIdleTestGame(t)
+	g.economyPaused = true
+	if g.activateBoost() {
+		t.Error("boost activated while the economy is paused")
+	}
+}
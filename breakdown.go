@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Multiplier line layout, the tooltip opens below it
+const (
+	multiplierLineX    = 20
+	multiplierLineY    = 100
+	multiplierLineSize = 24
+	breakdownLineSize  = 20
+	breakdownLineH     = 28
+	breakdownPadding   = 12
+)
+
+// ProductionFactor is one factor of the mana production product
+type ProductionFactor struct {
+	Name  string
+	Value float64
+}
+
+// ProductionBreakdown lists every factor multiplied into production, before the
+// baseline trickle floor is applied
+func (g *Game) ProductionBreakdown() []ProductionFactor {
+	var factors []ProductionFactor
+	for _, generator := range g.generators {
+		// Retired generators no longer contribute
+		if generator.retired {
+			continue
+		}
+		factors = append(factors, ProductionFactor{Name: generator.name, Value: generator.productionFactor()})
+	}
+
+	// Higher tiers boosting lower ones
+	factors = append(factors, ProductionFactor{Name: "Synergy", Value: g.totalSynergyMultiplier()})
+
+	// Permanent bonus bought with retirement tokens
+	factors = append(factors, ProductionFactor{Name: "Retirement tokens", Value: g.tokenProductionMultiplier()})
+
+	// Permanent bonus from prestiging
+	factors = append(factors, ProductionFactor{Name: "Ascension", Value: g.ascensionMultiplier()})
+
+	// Temporary "boost all" ultimate
+	factors = append(factors, ProductionFactor{Name: "Boost", Value: g.boostProductionMultiplier()})
+
+	// Random timed event
+	factors = append(factors, ProductionFactor{Name: "Mana surge", Value: g.surgeMultiplier()})
+	return factors
+}
+
+func (g *Game) multiplierLineFace() *text.GoTextFace {
+	return g.face(multiplierLineSize)
+}
+
+// Whether the cursor is over the multiplier line drawn as s
+func (g *Game) overMultiplierLine(s string) bool {
+	x, y := ebiten.CursorPosition()
+	w, h := text.Measure(s, g.multiplierLineFace(), 0)
+	return float64(x) >= multiplierLineX && float64(x) <= multiplierLineX+w &&
+		float64(y) >= multiplierLineY && float64(y) <= multiplierLineY+h
+}
+
+// Draw every production factor in a box below the multiplier line
+func (g *Game) drawProductionBreakdown(screen *ebiten.Image) {
+	lines := []string{"Production breakdown"}
+	for _, factor := range g.ProductionBreakdown() {
+		lines = append(lines, fmt.Sprintf("%s: x%.2f", factor.Name, factor.Value))
+	}
+	if raw := g.rawProductionMultiplier(); softCap(raw, g.config.SoftCap) < raw {
+		lines = append(lines, fmt.Sprintf("Soft capped above %s: %s", g.format(g.config.SoftCap), g.format(softCap(raw, g.config.SoftCap))))
+	}
+	if g.trickleApplied {
+		lines = append(lines, fmt.Sprintf("Raised to baseline trickle: %.2f", g.baselineProduction))
+	}
+	lines = append(lines, fmt.Sprintf("Total: %s/sec", g.format(g.totalMultiplier)))
+
+	face := g.face(breakdownLineSize)
+	width := 0.0
+	for _, line := range lines {
+		w, _ := text.Measure(line, face, 0)
+		width = max(width, w)
+	}
+
+	x := float32(multiplierLineX)
+	y := float32(multiplierLineY + multiplierLineSize + 10)
+	boxW := float32(width) + breakdownPadding*2
+	boxH := float32(len(lines)*breakdownLineH) + breakdownPadding*2
+	vector.DrawFilledRect(screen, x, y, boxW, boxH, color.RGBA{20, 20, 40, 235}, false)
+	vector.StrokeRect(screen, x, y, boxW, boxH, 1, color.RGBA{150, 150, 220, 255}, false)
+
+	for i, line := range lines {
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(x)+breakdownPadding, float64(y)+breakdownPadding+float64(i*breakdownLineH))
+		op.ColorScale.ScaleWithColor(color.RGBA{230, 230, 230, 255})
+		text.Draw(screen, line, face, op)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const buyMax = -1 // Buy quantity that buys as many levels as affordable
+
+// Buy quantities cycled by the toggle button
+var buyQuantities = []int{1, 10, 25, buyMax}
+
+// Quantities bought by clicking a generator with a modifier key held
+const (
+	shiftBuyQuantity = 10
+	ctrlBuyQuantity  = 100
+)
+
+// Buy quantity toggle layout (above the bottom left panel)
+const (
+	buyToggleX      = 30
+	buyToggleBottom = 250 // Distance of the toggle's top from the bottom edge
+	buyToggleW      = 160
+	buyToggleH      = 34
+)
+
+// Total cost of the next n levels of generator i, a geometric series starting at
+// its current cost, with the global discount applied
+func (g *Game) costForLevels(i, n int) float64 {
+	if n <= 0 {
+		return 0
+	}
+	cost, r := g.generators[i].cost*g.globalCostDiscount(), g.generators[i].costScaling
+	return cost * (math.Pow(r, float64(n)) - 1) / (r - 1)
+}
+
+// Mana charged for the next level of generator i
+func (g *Game) nextLevelCost(i int) float64 {
+	return g.generators[i].cost * g.globalCostDiscount()
+}
+
+// Number of levels of generator i the current mana pays for, up to the level cap.
+// Solves cost*(r^n-1)/(r-1) <= mana for n instead of pricing every count, so it's
+// cheap enough to show on every panel each frame.
+func (g *Game) maxAffordable(i int) int {
+	remaining := maxGeneratorLevel - g.generators[i].level
+	cost, r := g.nextLevelCost(i), g.generators[i].costScaling
+	if remaining <= 0 || !g.canAfford(cost) {
+		return 0
+	}
+	n := remaining
+	if x := math.Log(g.manaValue()*(r-1)/cost+1) / math.Log(r); x < float64(remaining) {
+		n = int(x)
+	}
+	// Rounding in the logarithm can leave the count one level off either way
+	for n > 0 && !g.canAfford(g.costForLevels(i, n)) {
+		n--
+	}
+	for n < remaining && g.canAfford(g.costForLevels(i, n+1)) {
+		n++
+	}
+	return n
+}
+
+// affordableCount is a maxAffordable result along with the inputs it was computed from
+type affordableCount struct {
+	valid bool
+	mana  BigNumber
+	level int
+	cost  float64 // Next level's cost, discount included
+	count int
+}
+
+// maxAffordable for the panels, recomputed only once the mana or generator i's
+// level or price changed
+func (g *Game) cachedMaxAffordable(i int) int {
+	if len(g.affordable) != len(g.generators) {
+		g.affordable = make([]affordableCount, len(g.generators))
+	}
+	c := &g.affordable[i]
+	level, cost := g.generators[i].level, g.nextLevelCost(i)
+	if !c.valid || c.mana != g.mana || c.level != level || c.cost != cost {
+		*c = affordableCount{valid: true, mana: g.mana, level: level, cost: cost, count: g.maxAffordable(i)}
+	}
+	return c.count
+}
+
+// Levels a purchase of n levels of generator i covers: every affordable one for
+// buyMax, trimmed to the level cap and the per-action cap
+func (g *Game) bulkLevels(i, n int) int {
+	if n == buyMax {
+		n = g.maxAffordable(i)
+	}
+	n = min(n, maxGeneratorLevel-g.generators[i].level)
+	if limit := g.settings.MaxBuyPerAction; limit > 0 {
+		n = min(n, limit)
+	}
+	return n
+}
+
+// Quantity generator i's panel buys: its own one picked with the mouse wheel, or
+// the buy toggle's
+func (g *Game) panelQuantity(i int) int {
+	if q := g.panelQuantities[i]; q != 0 {
+		return q
+	}
+	return g.buyQuantity
+}
+
+// Quantity a click on generator i buys: Shift and Ctrl override the panel's
+// quantity, and Ctrl wins when both are held
+func (g *Game) clickBuyQuantity(i int) int {
+	switch {
+	case ebiten.IsKeyPressed(ebiten.KeyControl):
+		return ctrlBuyQuantity
+	case ebiten.IsKeyPressed(ebiten.KeyShift):
+		return shiftBuyQuantity
+	}
+	return g.panelQuantity(i)
+}
+
+// Cycle the buy quantity of the panel under the cursor with the mouse wheel, up
+// for more. The wheel scrolls the shop list, so there only the buy buttons take it.
+func (g *Game) updatePanelQuantityWheel() {
+	_, dy := ebiten.Wheel()
+	if dy == 0 {
+		return
+	}
+	x, y := ebiten.CursorPosition()
+	i := g.generatorAt(x, y)
+	if g.shopListShown() {
+		i = g.shopBuyButtonAt(x, y)
+	}
+	if i < 0 {
+		return
+	}
+
+	step := 1
+	if dy < 0 {
+		step = -1
+	}
+	n := len(buyQuantities)
+	next := (max(0, slices.Index(buyQuantities, g.panelQuantity(i))) + step + n) % n
+	g.panelQuantities[i] = buyQuantities[next]
+}
+
+// Quantity label and total cost of what a click on generator i buys right now.
+// When nothing is affordable the cost is that of a single level.
+func (g *Game) clickPurchase(i int) (label string, cost float64) {
+	q := g.clickBuyQuantity(i)
+	return buyQuantityLabel(q), g.costForLevels(i, max(1, g.bulkLevels(i, q)))
+}
+
+// Buy n levels of generator i in one purchase, or as many as affordable for buyMax.
+// A fixed quantity is only bought if all of it is affordable; it's trimmed to the
+// level cap and the per-action cap. Returns how many levels were bought.
+func (g *Game) buyBulk(i, n int) int {
+	generator := &g.generators[i]
+	if g.economyFrozen() || generator.retired || i >= g.shownGenerators() {
+		return 0
+	}
+	n = g.bulkLevels(i, n)
+	total := g.costForLevels(i, n)
+	if n <= 0 || !g.canAfford(total) {
+		return 0
+	}
+
+	g.spend(total)
+	prevLevel, prevCost := generator.level, generator.cost
+	unlocked := generator.level == 0
+	generator.level += n
+	generator.cost *= math.Pow(generator.costScaling, float64(n))
+	g.recordPurchase(i, prevLevel, prevCost, total)
+	g.logEvent("bought %s level %d", generator.name, generator.level)
+
+	// First level unlocks the generator's orbit
+	if unlocked && !g.settings.ReduceMotion {
+		g.unlockAnimations[i] = unlockAnimationDuration
+	}
+
+	// Speed follows from level * speedPerLevel, only the production needs updating
+	g.calculateManaPerSec()
+	return n
+}
+
+func buyQuantityLabel(n int) string {
+	if n == buyMax {
+		return "Max"
+	}
+	return fmt.Sprintf("x%d", n)
+}
+
+// Handle a click on the buy quantity toggle, reporting whether it was consumed
+func (g *Game) handleBuyToggleClick(x, y int) bool {
+	buyToggleY := g.height - buyToggleBottom
+	if x >= buyToggleX && x <= buyToggleX+buyToggleW && y >= buyToggleY && y <= buyToggleY+buyToggleH {
+		g.buyQuantity = nextInCycle(buyQuantities, g.buyQuantity)
+		// The toggle sets every panel, dropping quantities picked with the wheel
+		clear(g.panelQuantities)
+		return true
+	}
+	return false
+}
+
+func (g *Game) drawBuyToggle(screen *ebiten.Image) {
+	buyToggleY := float32(g.height - buyToggleBottom)
+	vector.DrawFilledRect(screen, buyToggleX, buyToggleY, buyToggleW, buyToggleH, color.RGBA{60, 60, 90, 255}, false)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(buyToggleX+10, float64(buyToggleY)+6)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, "Buy: "+buyQuantityLabel(g.buyQuantity), g.face(18), op)
+}
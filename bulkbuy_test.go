@@ -0,0 +1,228 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBuyBulkPerActionCap(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		n     int
+		want  int
+	}{
+		{"buy max trimmed to the cap", 25, buyMax, 25},
+		{"fixed quantity trimmed to the cap", 25, 100, 25},
+		{"quantity under the cap", 25, 10, 10},
+		{"unlimited", 0, 100, 100},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.settings.MaxBuyPerAction = tt.limit
+		g.setMana(1e30)
+		if got := g.buyBulk(0, tt.n); got != tt.want {
+			t.Errorf("%s: bought %d levels, want %d", tt.name, got, tt.want)
+		}
+		if g.generators[0].level != tt.want {
+			t.Errorf("%s: level = %d, want %d", tt.name, g.generators[0].level, tt.want)
+		}
+	}
+}
+
+func TestBuyLevelsHeldKeyCap(t *testing.T) {
+	// Repeats of a held buy key share one action's cap
+	g := newIdleTestGame(t)
+	g.settings.MaxBuyPerAction = 25
+	g.setMana(1e30)
+	done := 0
+	for range 4 {
+		done += g.buyLevels(0, 10, done)
+	}
+	if done != 25 || g.generators[0].level != 25 {
+		t.Errorf("held key bought %d levels (level %d), want 25", done, g.generators[0].level)
+	}
+}
+
+func TestIsDoubleClick(t *testing.T) {
+	tests := []struct {
+		name   string
+		last   int
+		lastAt float64
+		i      int
+		now    float64
+		window float64
+		want   bool
+	}{
+		{"second click in the window", 2, 1, 2, 1.3, 0.4, true},
+		{"second click at the window's end", 2, 1, 2, 1.4, 0.4, true},
+		{"second click too late", 2, 1, 2, 1.5, 0.4, false},
+		{"other panel", 1, 1, 2, 1.1, 0.4, false},
+		{"after a double-click", -1, 1, 2, 1.1, 0.4, false},
+		{"disabled", 2, 1, 2, 1.1, 0, false},
+	}
+	for _, tt := range tests {
+		if got := isDoubleClick(tt.last, tt.lastAt, tt.i, tt.now, tt.window); got != tt.want {
+			t.Errorf("%s: isDoubleClick() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGeneratorPanelDoubleClick(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.Layout(screenWidth, screenHeight)
+	g.settings.MaxBuyPerAction = 0
+	g.settings.DoubleClickWindow = 400
+	g.setMana(1e6)
+	x, y, w, h := g.generatorRect(0)
+	click := func() int {
+		before := g.generators[0].level
+		g.handleGeneratorClicks(x+w/2, y+h/2)
+		return g.generators[0].level - before
+	}
+
+	if n := click(); n != 1 {
+		t.Fatalf("single click bought %d levels, want 1", n)
+	}
+	g.animationTime += 0.2
+	want := g.maxAffordable(0)
+	if n := click(); n != want || want <= 1 {
+		t.Errorf("double-click bought %d levels, want all %d affordable", n, want)
+	}
+
+	// A third click starts over instead of extending the double-click
+	g.setMana(1e6)
+	g.animationTime += 0.1
+	if n := click(); n != 1 {
+		t.Errorf("click after a double-click bought %d levels, want 1", n)
+	}
+	g.animationTime += 1
+	if n := click(); n != 1 {
+		t.Errorf("click after the window bought %d levels, want 1", n)
+	}
+}
+
+func TestCostForLevels(t *testing.T) {
+	tests := []struct {
+		cost, scaling float64
+		n             int
+	}{
+		{10, 1.15, 0},
+		{10, 1.15, 1},
+		{10, 1.15, 10},
+		{250, 1.5, 25},
+		{1e6, 1.07, 100},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.tokenDiscountLevel = 3
+		g.generators[0].cost, g.generators[0].costScaling = tt.cost, tt.scaling
+
+		// Price each level on its own
+		want, cost := 0.0, tt.cost*g.globalCostDiscount()
+		for range tt.n {
+			want += cost
+			cost *= tt.scaling
+		}
+		if got := g.costForLevels(0, tt.n); math.Abs(got-want) > want*1e-12 {
+			t.Errorf("costForLevels(cost %v, scaling %v, %d) = %v, want %v", tt.cost, tt.scaling, tt.n, got, want)
+		}
+	}
+}
+
+func TestCostForLevelsMatchesSinglePurchases(t *testing.T) {
+	bulk := newIdleTestGame(t)
+	bulk.settings.MaxBuyPerAction = 0
+	bulk.setMana(1000)
+	total := bulk.costForLevels(0, 10)
+	if bulk.buyBulk(0, 10) != 10 {
+		t.Fatal("bulk purchase failed")
+	}
+
+	single := newIdleTestGame(t)
+	single.setMana(1000)
+	for range 10 {
+		if !single.buyGenerator(0) {
+			t.Fatal("single purchase failed")
+		}
+	}
+	if math.Abs(bulk.manaValue()-single.manaValue()) > 1e-9 || math.Abs(1000-bulk.manaValue()-total) > 1e-9 {
+		t.Errorf("10 levels left %v mana in bulk and %v one by one, cost %v", bulk.manaValue(), single.manaValue(), total)
+	}
+	if math.Abs(bulk.generators[0].cost-single.generators[0].cost) > single.generators[0].cost*1e-12 {
+		t.Errorf("next level costs %v after bulk and %v after single purchases", bulk.generators[0].cost, single.generators[0].cost)
+	}
+}
+
+func TestMaxAffordable(t *testing.T) {
+	tests := []struct {
+		name  string
+		level int
+		mana  func(g *Game) float64
+		want  int
+	}{
+		{"nothing", 0, func(g *Game) float64 { return 0 }, 0},
+		{"just short of one level", 0, func(g *Game) float64 { return g.nextLevelCost(0) * 0.999 }, 0},
+		{"exactly one level", 0, func(g *Game) float64 { return g.nextLevelCost(0) }, 1},
+		{"exactly seven levels", 0, func(g *Game) float64 { return g.costForLevels(0, 7) }, 7},
+		{"just short of seven levels", 0, func(g *Game) float64 { return g.costForLevels(0, 7) * 0.999 }, 6},
+		{"capped at the max level", 0, func(g *Game) float64 { return g.costForLevels(0, maxGeneratorLevel) * 10 }, maxGeneratorLevel},
+		{"capped by the levels left", 95, func(g *Game) float64 { return g.costForLevels(0, 20) }, 5},
+		{"at the max level", maxGeneratorLevel, func(g *Game) float64 { return 1e300 }, 0},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.storageLevel = 400
+		g.generators[0].level = tt.level
+		g.setMana(tt.mana(g))
+		if got := g.maxAffordable(0); got != tt.want {
+			t.Errorf("%s: maxAffordable(0) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMaxAffordableMatchesCounting(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.storageLevel = 400
+	for _, mana := range []float64{10, 123, 4567, 1e5, 3.3e7, 1e12} {
+		g.setMana(mana)
+		want := 0
+		for want < maxGeneratorLevel && g.canAfford(g.costForLevels(0, want+1)) {
+			want++
+		}
+		if got := g.maxAffordable(0); got != want {
+			t.Errorf("%v mana: maxAffordable(0) = %d, counting gives %d", mana, got, want)
+		}
+	}
+}
+
+func TestCachedMaxAffordable(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.settings.MaxBuyPerAction = 0
+	g.setMana(g.costForLevels(0, 7))
+	if got := g.cachedMaxAffordable(0); got != 7 {
+		t.Fatalf("cachedMaxAffordable(0) = %d, want 7", got)
+	}
+
+	// Unchanged inputs reuse the cached count
+	g.affordable[0].count = 99
+	if got := g.cachedMaxAffordable(0); got != 99 {
+		t.Errorf("count was recomputed although nothing changed, got %d", got)
+	}
+
+	tests := []struct {
+		name   string
+		change func(g *Game)
+	}{
+		{"mana", func(g *Game) { g.addMana(1) }},
+		{"purchase", func(g *Game) { g.buyBulk(0, 2) }},
+		{"discount", func(g *Game) { g.tokenDiscountLevel++ }},
+	}
+	for _, tt := range tests {
+		g.affordable[0].count = 99
+		tt.change(g)
+		if got, want := g.cachedMaxAffordable(0), g.maxAffordable(0); got != want {
+			t.Errorf("after a %s change: cachedMaxAffordable(0) = %d, want %d", tt.name, got, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Orbit view limits
+const (
+	minOrbitZoom  = 0.5
+	maxOrbitZoom  = 3.0
+	orbitZoomStep = 0.1 // Zoom change per wheel notch
+)
+
+// Reset view button layout (bottom center, above the trickle button)
+const (
+	viewResetWidth  = 140
+	viewResetHeight = 30
+	viewResetBottom = 195 // Distance of the button's top from the bottom edge
+)
+
+// Start panning the orbit view from a click that hit nothing else
+func (g *Game) startOrbitDrag(x, y int) {
+	g.orbitDragging = true
+	g.dragLastX, g.dragLastY = x, y
+}
+
+// Pan with a left-button drag and zoom with the mouse wheel
+func (g *Game) updateOrbitView() {
+	x, y := ebiten.CursorPosition()
+	if g.orbitDragging {
+		if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) || inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+			g.orbitDragging = false
+		} else {
+			g.orbitPanX += float64(x - g.dragLastX)
+			g.orbitPanY += float64(y - g.dragLastY)
+			g.dragLastX, g.dragLastY = x, y
+		}
+	}
+
+	// Only zoom when the cursor is over the orbit area rather than a panel or the shop list
+	if _, dy := ebiten.Wheel(); dy != 0 && g.generatorAt(x, y) < 0 && !g.overShop(x, y) {
+		g.orbitZoom += dy * orbitZoomStep
+	}
+
+	// Keep the center on screen
+	maxPanX, maxPanY := float64(g.width)/2, float64(g.height)/2
+	g.orbitPanX = max(-maxPanX, min(maxPanX, g.orbitPanX))
+	g.orbitPanY = max(-maxPanY, min(maxPanY, g.orbitPanY))
+	g.orbitZoom = max(minOrbitZoom, min(maxOrbitZoom, g.orbitZoom))
+}
+
+// Whether the orbit view differs from the default
+func (g *Game) orbitViewMoved() bool {
+	return g.orbitPanX != 0 || g.orbitPanY != 0 || g.orbitZoom != 1
+}
+
+func (g *Game) viewResetRect() (x, y, w, h int) {
+	return g.width/2 - viewResetWidth/2, g.height - viewResetBottom, viewResetWidth, viewResetHeight
+}
+
+// Handle a click on the reset view button, reporting whether it was consumed
+func (g *Game) handleViewResetClick(x, y int) bool {
+	if !g.orbitViewMoved() {
+		return false
+	}
+	bx, by, bw, bh := g.viewResetRect()
+	if x >= bx && x <= bx+bw && y >= by && y <= by+bh {
+		g.orbitPanX, g.orbitPanY, g.orbitZoom = 0, 0, 1
+		return true
+	}
+	return false
+}
+
+// Apply the pan offset to a center point and return it with the scale factor,
+// which combines the zoom with the window size
+func (g *Game) orbitView(centerX, centerY float32) (float32, float32, float32) {
+	return centerX + float32(g.orbitPanX), centerY + float32(g.orbitPanY), float32(g.orbitZoom * g.uiScale())
+}
+
+func (g *Game) drawViewReset(screen *ebiten.Image) {
+	if !g.orbitViewMoved() {
+		return
+	}
+
+	x, y, w, h := g.viewResetRect()
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), color.RGBA{60, 60, 90, 255}, false)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x+22), float64(y+5))
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, "Reset view", g.face(16), op)
+}
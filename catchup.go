@@ -0,0 +1,32 @@
+package main
+
+import "time"
+
+// Longest background stretch credited on return, matching a long lunch break
+const maxCatchUp = 2 * time.Hour
+
+// Credit production for time the game spent in a background browser tab, where
+// ticks are throttled or stop entirely and real-time accrual caps each tick
+func (g *Game) applyBackgroundCatchUp() {
+	g.creditBackgroundTime(takeBackgroundTime())
+}
+
+// Credit elapsed seconds of background production unless the economy is on
+// hold, which it stays while the tab is hidden
+func (g *Game) creditBackgroundTime(elapsed time.Duration) {
+	if elapsed <= 0 || !g.settings.TabCatchUp || g.economyFrozen() || g.settings.PauseOnBlur {
+		return
+	}
+
+	gain := catchUpMana(g.totalMultiplier, elapsed)
+	g.addMana(gain)
+	g.logEvent("credited %.0fs in the background", min(elapsed, maxCatchUp).Seconds())
+}
+
+// Mana earned at rate per second over elapsed, capped at maxCatchUp
+func catchUpMana(rate float64, elapsed time.Duration) float64 {
+	if rate <= 0 || elapsed <= 0 {
+		return 0
+	}
+	return rate * min(elapsed, maxCatchUp).Seconds()
+}
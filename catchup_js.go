@@ -0,0 +1,40 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// Time the page spent hidden, reported by the visibilitychange listener
+var background struct {
+	sync.Mutex
+	hiddenSince time.Time
+	pending     time.Duration
+}
+
+func init() {
+	document := js.Global().Get("document")
+	document.Call("addEventListener", "visibilitychange", js.FuncOf(func(js.Value, []js.Value) any {
+		background.Lock()
+		defer background.Unlock()
+		if document.Get("visibilityState").String() == "hidden" {
+			background.hiddenSince = time.Now()
+		} else if !background.hiddenSince.IsZero() {
+			background.pending += time.Since(background.hiddenSince)
+			background.hiddenSince = time.Time{}
+		}
+		return nil
+	}))
+}
+
+// Return and reset the hidden time accumulated since the last call
+func takeBackgroundTime() time.Duration {
+	background.Lock()
+	defer background.Unlock()
+	d := background.pending
+	background.pending = 0
+	return d
+}
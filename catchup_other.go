@@ -0,0 +1,10 @@
+//go:build !(js && wasm)
+
+package main
+
+import "time"
+
+// Desktop builds keep ticking in the background, so there is nothing to catch up
+func takeBackgroundTime() time.Duration {
+	return 0
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCatchUpMana(t *testing.T) {
+	tests := []struct {
+		rate    float64
+		elapsed time.Duration
+		want    float64
+	}{
+		{2, 0, 0},
+		{0, time.Hour, 0},
+		{-1, time.Hour, 0},
+		{2, 10 * time.Second, 20},
+		{2, maxCatchUp, 2 * maxCatchUp.Seconds()},
+		{2, 3 * time.Hour, 2 * maxCatchUp.Seconds()},
+	}
+	for _, tt := range tests {
+		if got := catchUpMana(tt.rate, tt.elapsed); got != tt.want {
+			t.Errorf("catchUpMana(%v, %v) = %v, want %v", tt.rate, tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestCreditBackgroundTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(g *Game)
+		elapsed time.Duration
+		credits time.Duration // Time paid for
+	}{
+		{"a minute", func(g *Game) {}, time.Minute, time.Minute},
+		{"capped at two hours", func(g *Game) {}, 5 * time.Hour, maxCatchUp},
+		{"disabled", func(g *Game) { g.settings.TabCatchUp = false }, time.Minute, 0},
+		{"paused", func(g *Game) { g.paused = true }, time.Minute, 0},
+		{"economy paused", func(g *Game) { g.economyPaused = true }, time.Minute, 0},
+		{"options open", func(g *Game) { g.optionsOpen = true }, time.Minute, 0},
+		{"pause on blur", func(g *Game) { g.settings.PauseOnBlur = true }, time.Minute, 0},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.settings.TabCatchUp = true
+		g.settings.PauseOnBlur = false
+		tt.setup(g)
+		g.creditBackgroundTime(tt.elapsed)
+		if want := g.totalMultiplier * tt.credits.Seconds(); g.manaValue() != want {
+			t.Errorf("%s: mana = %v, want %v", tt.name, g.manaValue(), want)
+		}
+	}
+}
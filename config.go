@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+)
+
+const defaultConfigPath = "config.json" // Optional balance overrides for the generators
+
+// GeneratorConfig holds the starting values of one generator
+type GeneratorConfig struct {
+	Name          string  `json:"name"`
+	Cost          float64 `json:"cost"`          // Cost of the first level
+	SpeedPerLevel float64 `json:"speedPerLevel"` // Rotation speed added per level
+	ScalingFactor float64 `json:"scalingFactor"` // Cost growth per level
+	Description   string  `json:"description"`
+	Level         int     `json:"level"`                 // Levels owned at the start of a run
+	MinPrestige   int     `json:"minPrestige,omitempty"` // Prestiges needed before the generator shows up
+}
+
+// SynergyConfig makes each level of the Source generator raise the Target
+// generator's multiplier by BonusPerLevel (0.01 is 1%)
+type SynergyConfig struct {
+	Source        string  `json:"source"`
+	Target        string  `json:"target"`
+	BonusPerLevel float64 `json:"bonusPerLevel"`
+}
+
+// SurgeConfig tunes the random mana surge event
+type SurgeConfig struct {
+	Chance     float64 `json:"chance"`     // Probability of a surge each minute, 0 turns surges off
+	Multiplier float64 `json:"multiplier"` // Production factor during a surge
+	Duration   float64 `json:"duration"`   // Seconds a surge lasts
+}
+
+// Config holds the game balance, read from config.json when present
+type Config struct {
+	StartingMana float64           `json:"startingMana"`
+	SoftCap      float64           `json:"softCap"` // Production beyond this has diminishing returns, 0 means off
+	Generators   []GeneratorConfig `json:"generators"`
+	Synergies    []SynergyConfig   `json:"synergies"`
+	Surge        SurgeConfig       `json:"surge"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Generators: []GeneratorConfig{
+			{Name: "Mana Crystal", Cost: 3.0, SpeedPerLevel: 0.1, ScalingFactor: 1.15, Description: "Basic mana generation crystal", Level: 5}, // Slower cost scaling
+			{Name: "Arcane Tower", Cost: 50.0, SpeedPerLevel: 0.08, ScalingFactor: 1.2, Description: "Mystical mana channeling tower"},
+			{Name: "Ley Line Node", Cost: 250.0, SpeedPerLevel: 0.05, ScalingFactor: 1.2, Description: "Powerful magical energy nexus"},
+			{Name: "Elder Artifact", Cost: 1000.0, SpeedPerLevel: 0.02, ScalingFactor: 1.2, Description: "Ancient relic of immense power"},
+			{Name: "Astral Forge", Cost: 5000.0, SpeedPerLevel: 0.015, ScalingFactor: 1.22, Description: "Smelts starlight into raw mana"},
+			{Name: "Void Conduit", Cost: 25000.0, SpeedPerLevel: 0.01, ScalingFactor: 1.25, Description: "Draws power from the space between worlds"},
+			{Name: "Celestial Loom", Cost: 150000.0, SpeedPerLevel: 0.008, ScalingFactor: 1.25, Description: "Weaves the threads of fate into mana"},
+			{Name: "Eternity Engine", Cost: 1000000.0, SpeedPerLevel: 0.005, ScalingFactor: 1.3, Description: "Timeless machine that never stops turning"},
+			{Name: "Ascendant Spire", Cost: 10000000.0, SpeedPerLevel: 0.004, ScalingFactor: 1.3, Description: "Rises only for those who have ascended", MinPrestige: 1},
+			{Name: "Primordial Wellspring", Cost: 100000000.0, SpeedPerLevel: 0.003, ScalingFactor: 1.35, Description: "The source every other mana flows from", MinPrestige: 3},
+		},
+		Surge: SurgeConfig{Chance: 0.1, Multiplier: 2, Duration: 30},
+		// Every tier boosts the one below it
+		Synergies: []SynergyConfig{
+			{Source: "Arcane Tower", Target: "Mana Crystal", BonusPerLevel: 0.01},
+			{Source: "Ley Line Node", Target: "Arcane Tower", BonusPerLevel: 0.01},
+			{Source: "Elder Artifact", Target: "Ley Line Node", BonusPerLevel: 0.01},
+			{Source: "Astral Forge", Target: "Elder Artifact", BonusPerLevel: 0.01},
+			{Source: "Void Conduit", Target: "Astral Forge", BonusPerLevel: 0.01},
+			{Source: "Celestial Loom", Target: "Void Conduit", BonusPerLevel: 0.01},
+			{Source: "Eternity Engine", Target: "Celestial Loom", BonusPerLevel: 0.01},
+		},
+	}
+}
+
+// loadConfig reads the balance from path, keeping defaults for anything missing
+func loadConfig(path string) (Config, error) {
+	c := defaultConfig()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return defaultConfig(), err
+	}
+	if err := c.validate(); err != nil {
+		return defaultConfig(), err
+	}
+	return c, nil
+}
+
+// Reject values the economy can't run on. JSON has no NaN or infinity, so only
+// ranges need checking.
+func (c Config) validate() error {
+	if len(c.Generators) == 0 {
+		return errors.New("config: no generators")
+	}
+	if c.StartingMana < 0 {
+		return fmt.Errorf("config: invalid startingMana %v", c.StartingMana)
+	}
+	if c.SoftCap < 0 {
+		return fmt.Errorf("config: invalid softCap %v", c.SoftCap)
+	}
+	for i, gc := range c.Generators {
+		switch {
+		case gc.Cost <= 0:
+			return fmt.Errorf("config: %s: invalid cost %v", gc.Name, gc.Cost)
+		case gc.SpeedPerLevel < 0:
+			return fmt.Errorf("config: %s: invalid speedPerLevel %v", gc.Name, gc.SpeedPerLevel)
+		case gc.ScalingFactor <= 1:
+			return fmt.Errorf("config: %s: scalingFactor must be above 1, got %v", gc.Name, gc.ScalingFactor)
+		case gc.Level < 0 || gc.Level > maxGeneratorLevel:
+			return fmt.Errorf("config: %s: invalid level %d", gc.Name, gc.Level)
+		case gc.MinPrestige < 0:
+			return fmt.Errorf("config: %s: invalid minPrestige %d", gc.Name, gc.MinPrestige)
+		// Generators show up in order, so gated tiers must come last
+		case i > 0 && gc.MinPrestige < c.Generators[i-1].MinPrestige:
+			return fmt.Errorf("config: %s: minPrestige %d is below the previous generator's", gc.Name, gc.MinPrestige)
+		}
+	}
+	switch {
+	case c.Surge.Chance < 0 || c.Surge.Chance > 1:
+		return fmt.Errorf("config: surge chance must be between 0 and 1, got %v", c.Surge.Chance)
+	case c.Surge.Multiplier < 1:
+		return fmt.Errorf("config: invalid surge multiplier %v", c.Surge.Multiplier)
+	case c.Surge.Duration <= 0:
+		return fmt.Errorf("config: invalid surge duration %v", c.Surge.Duration)
+	}
+	for _, sc := range c.Synergies {
+		if sc.BonusPerLevel < 0 {
+			return fmt.Errorf("config: synergy %s -> %s: invalid bonusPerLevel %v", sc.Source, sc.Target, sc.BonusPerLevel)
+		}
+	}
+	return nil
+}
+
+// Starting state of the generators, also restored by a prestige
+func (c Config) generators() []Generator {
+	gens := make([]Generator, len(c.Generators))
+	for i, gc := range c.Generators {
+		gens[i] = Generator{
+			name:           gc.Name,
+			cost:           gc.Cost,
+			speedPerLevel:  gc.SpeedPerLevel,
+			level:          gc.Level,
+			description:    gc.Description,
+			manaMultiplier: 1.0,
+			multiplierGain: rotationMultiplierGain,
+			costScaling:    gc.ScalingFactor,
+			minPrestige:    gc.MinPrestige,
+			enabled:        true,
+		}
+	}
+	return gens
+}
+
+// Load the balance from configPath, falling back to the built-in defaults
+func (g *Game) loadConfig() {
+	c, err := loadConfig(g.configPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("failed to load config: %v", err)
+	}
+	g.config = c
+	g.synergies = c.synergies()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCostScalingPerGenerator(t *testing.T) {
+	// The original four generators; the later tiers scale faster on purpose
+	want := map[string]float64{
+		"Mana Crystal":   1.15,
+		"Arcane Tower":   1.2,
+		"Ley Line Node":  1.2,
+		"Elder Artifact": 1.2,
+	}
+	g := newTestGame(t)
+	for i := range g.generators {
+		gen := &g.generators[i]
+		factor, ok := want[gen.name]
+		if !ok {
+			continue
+		}
+		delete(want, gen.name)
+		if gen.costScaling != factor {
+			t.Errorf("%s: costScaling = %v, want %v", gen.name, gen.costScaling, factor)
+		}
+		for range 5 {
+			g.setMana(1e12)
+			prev := gen.cost
+			if g.buyBulk(i, 1) != 1 {
+				t.Fatalf("%s: purchase failed", gen.name)
+			}
+			if got := gen.cost / prev; math.Abs(got-factor) > 1e-9 {
+				t.Errorf("%s: cost grew by %v, want %v", gen.name, got, factor)
+			}
+		}
+	}
+	for name := range want {
+		t.Errorf("generator %s missing from the defaults", name)
+	}
+}
+
+func TestConfigValidateMinPrestigeOrder(t *testing.T) {
+	c := defaultConfig()
+	if err := c.validate(); err != nil {
+		t.Fatalf("defaults are invalid: %v", err)
+	}
+	c.Generators[0].MinPrestige = 1
+	if err := c.validate(); err == nil {
+		t.Error("a gated generator before an ungated one was accepted")
+	}
+	c.Generators[0].MinPrestige = -1
+	if err := c.validate(); err == nil {
+		t.Error("a negative minPrestige was accepted")
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Lucky orb clicks pay a multiple of the click value
+const (
+	baseCritChance     = 0.05 // Chance of a lucky click before upgrades
+	critChanceStep     = 0.01 // Chance added per upgrade
+	maxCritChance      = 0.50
+	baseCritMultiplier = 10.0 // Click value multiple a lucky click pays before upgrades
+	critMultiplierStep = 2.0  // Multiple added per upgrade
+
+	critChanceBaseCost     = 100.0
+	critMultiplierBaseCost = 250.0
+	critCostScaling        = 4.0 // Cost growth per upgrade level
+)
+
+// critStat identifies one of the upgradeable lucky click stats
+type critStat int
+
+const (
+	critStatChance critStat = iota
+	critStatMultiplier
+)
+
+// Crit upgrade buttons layout (bottom left, stacked above the buy toggle)
+const (
+	critButtonX      = 30
+	critButtonBottom = 340 // Distance of the upper button's top from the bottom edge
+	critButtonW      = 240
+	critButtonH      = 34
+	critButtonSpace  = 45
+)
+
+// Chance that an orb click is lucky
+func (g *Game) critChance() float64 {
+	return min(maxCritChance, baseCritChance+critChanceStep*float64(g.critChanceLevel))
+}
+
+// Click value multiple a lucky click pays
+func (g *Game) critMultiplier() float64 {
+	return baseCritMultiplier + critMultiplierStep*float64(g.critMultiplierLevel)
+}
+
+// Roll whether the next orb click is lucky, drawing from the game's seeded source
+func (g *Game) rollCrit() bool {
+	return g.rng.Float64() < g.critChance()
+}
+
+// Mana needed for the next upgrade of stat
+func (g *Game) critUpgradeCost(stat critStat) float64 {
+	if stat == critStatChance {
+		return critChanceBaseCost * math.Pow(critCostScaling, float64(g.critChanceLevel))
+	}
+	return critMultiplierBaseCost * math.Pow(critCostScaling, float64(g.critMultiplierLevel))
+}
+
+// Raise a lucky click stat
+func (g *Game) buyCritUpgrade(stat critStat) bool {
+	cost := g.critUpgradeCost(stat)
+	if g.economyFrozen() || !g.canAfford(cost) {
+		return false
+	}
+	switch stat {
+	case critStatChance:
+		if g.critChance() >= maxCritChance {
+			return false
+		}
+		g.critChanceLevel++
+		g.logEvent("raised lucky click chance to %.0f%%", g.critChance()*100)
+	case critStatMultiplier:
+		g.critMultiplierLevel++
+		g.logEvent("raised lucky click reward to x%.0f", g.critMultiplier())
+	default:
+		return false
+	}
+	g.spend(cost)
+	return true
+}
+
+func (g *Game) critButtonRect(stat critStat) (x, y, w, h int) {
+	return critButtonX, g.height - critButtonBottom + int(stat)*critButtonSpace, critButtonW, critButtonH
+}
+
+// Handle a click on a crit upgrade button, reporting whether it was consumed
+func (g *Game) handleCritClicks(x, y int) bool {
+	for _, stat := range []critStat{critStatChance, critStatMultiplier} {
+		bx, by, bw, bh := g.critButtonRect(stat)
+		if x >= bx && x <= bx+bw && y >= by && y <= by+bh {
+			g.buyCritUpgrade(stat)
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) drawCritButtons(screen *ebiten.Image) {
+	labels := []string{
+		critStatChance:     fmt.Sprintf("Luck %.0f%%: %s", g.critChance()*100, g.format(g.critUpgradeCost(critStatChance))),
+		critStatMultiplier: fmt.Sprintf("Lucky x%.0f: %s", g.critMultiplier(), g.format(g.critUpgradeCost(critStatMultiplier))),
+	}
+	if g.critChance() >= maxCritChance {
+		labels[critStatChance] = fmt.Sprintf("Luck %.0f%% (max)", g.critChance()*100)
+	}
+	for _, stat := range []critStat{critStatChance, critStatMultiplier} {
+		x, y, w, h := g.critButtonRect(stat)
+		fill := color.RGBA{60, 60, 60, 255}
+		if g.canAfford(g.critUpgradeCost(stat)) {
+			fill = color.RGBA{110, 90, 30, 255}
+		}
+		vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), fill, false)
+
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(x)+10, float64(y)+7)
+		op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+		text.Draw(screen, labels[stat], g.face(16), op)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import "testing"
+
+func TestLuckyClick(t *testing.T) {
+	tests := []struct {
+		name            string
+		roll            float64
+		multiplierLevel int
+		want            float64 // Click values awarded
+	}{
+		{"lucky", 0, 0, baseCritMultiplier},
+		{"just under the chance", baseCritChance - 1.0/1024, 0, baseCritMultiplier},
+		{"just over the chance", baseCritChance + 1.0/1024, 0, 1},
+		{"normal", 0.99, 0, 1},
+		{"lucky with upgrades", 0, 3, baseCritMultiplier + 3*critMultiplierStep},
+	}
+	for _, tt := range tests {
+		g := newTestGame(t, WithRand(scriptedRand(tt.roll)))
+		g.storageLevel = 5
+		g.setMana(0)
+		g.critMultiplierLevel = tt.multiplierLevel
+		if !g.clickOrb() {
+			t.Fatalf("%s: click wasn't counted", tt.name)
+		}
+		if want := g.orbClickValue * tt.want; g.manaValue() != want {
+			t.Errorf("%s: click awarded %v mana, want %v", tt.name, g.manaValue(), want)
+		}
+	}
+}
+
+func TestCritChanceCapped(t *testing.T) {
+	g := newTestGame(t)
+	g.critChanceLevel = 1000
+	if got := g.critChance(); got != maxCritChance {
+		t.Errorf("critChance() = %v at level 1000, want %v", got, maxCritChance)
+	}
+}
+
+func TestBuyCritUpgrade(t *testing.T) {
+	for _, stat := range []critStat{critStatChance, critStatMultiplier} {
+		g := newTestGame(t)
+		g.storageLevel = 5
+		cost := g.critUpgradeCost(stat)
+		g.setMana(cost)
+		if !g.buyCritUpgrade(stat) {
+			t.Fatalf("stat %d: affordable upgrade wasn't bought", stat)
+		}
+		if g.manaValue() != 0 || g.critChanceLevel+g.critMultiplierLevel != 1 {
+			t.Errorf("stat %d: mana %v, levels %d and %d after the upgrade", stat, g.manaValue(), g.critChanceLevel, g.critMultiplierLevel)
+		}
+		if next := g.critUpgradeCost(stat); next != cost*critCostScaling {
+			t.Errorf("stat %d: next upgrade costs %v, want %v", stat, next, cost*critCostScaling)
+		}
+	}
+}
+
+func TestBuyCritUpgradeRefused(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(g *Game)
+	}{
+		{"unaffordable", func(g *Game) { g.setMana(g.critUpgradeCost(critStatChance) - 1) }},
+		{"economy paused", func(g *Game) { g.economyPaused = true }},
+		{"options open", func(g *Game) { g.optionsOpen = true }},
+		{"chance at the max", func(g *Game) { g.critChanceLevel = 1000 }},
+	}
+	for _, tt := range tests {
+		g := newTestGame(t)
+		g.storageLevel = 50
+		g.setMana(1e40)
+		tt.setup(g)
+		mana, level := g.manaValue(), g.critChanceLevel
+		if g.buyCritUpgrade(critStatChance) {
+			t.Errorf("%s: upgrade was bought", tt.name)
+		}
+		if g.manaValue() != mana || g.critChanceLevel != level {
+			t.Errorf("%s: refused upgrade changed mana to %v and level to %d", tt.name, g.manaValue(), g.critChanceLevel)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const dailyDateLayout = "2006-01-02"
+
+// Seed shared by every player on the given calendar day (in UTC)
+func dailySeed(date time.Time) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(date.UTC().Format(dailyDateLayout)))
+	return h.Sum64()
+}
+
+// Each day's challenge keeps its own save, apart from the regular slots
+func dailySavePath(date time.Time) string {
+	return addPathSuffix(defaultSavePath, "-daily-"+date.UTC().Format("20060102"))
+}
+
+// Start or resume the daily challenge for date, with randomness locked to its seed.
+// Settings are shared with the active slot.
+func newDailyChallenge(date time.Time, opts ...GameOption) *Game {
+	slot := loadActiveSlot()
+	_, settingsPath := slotPaths(slot)
+	g := newGameWithPaths(slot, dailySavePath(date), settingsPath, append(opts, WithSeed(dailySeed(date)))...)
+	g.dailyDate = date.UTC().Format(dailyDateLayout)
+	return g
+}
+
+func (g *Game) drawDailyChallenge(screen *ebiten.Image) {
+	if g.dailyDate == "" {
+		return
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(g.width-400), 20)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 215, 100, 255})
+	text.Draw(screen, fmt.Sprintf("Daily challenge %s (seed %d)", g.dailyDate, g.seed), g.face(18), op)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailySeedStable(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	day := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		date time.Time
+		same bool // Same challenge as day
+	}{
+		{"same instant", day, true},
+		{"later that day", day.Add(23*time.Hour + 59*time.Minute), true},
+		{"same UTC day in another zone", time.Date(2024, 3, 15, 20, 0, 0, 0, tokyo), true},
+		{"next day", day.AddDate(0, 0, 1), false},
+		{"previous day", day.Add(-time.Second), false},
+		{"a year later", day.AddDate(1, 0, 0), false},
+	}
+	for _, tt := range tests {
+		if got := dailySeed(tt.date) == dailySeed(day); got != tt.same {
+			t.Errorf("%s: same seed = %v, want %v", tt.name, got, tt.same)
+		}
+		if got := dailySavePath(tt.date) == dailySavePath(day); got != tt.same {
+			t.Errorf("%s: same save = %v, want %v", tt.name, got, tt.same)
+		}
+	}
+}
+
+func TestDailySeedLocksRandomness(t *testing.T) {
+	seed := dailySeed(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+	a := newTestGame(t, WithSeed(seed))
+	b := newTestGame(t, WithSeed(seed))
+	for range 10 {
+		if x, y := a.rng.Float64(), b.rng.Float64(); x != y {
+			t.Fatalf("rolls diverged: %v and %v", x, y)
+		}
+	}
+}
+
+func TestDailySavePathApartFromSlots(t *testing.T) {
+	path := dailySavePath(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+	for _, slot := range slotNumbers() {
+		if savePath, _ := slotPaths(slot); savePath == path {
+			t.Errorf("daily challenge shares slot %d's save %s", slot, path)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log"
+	"math/rand/v2"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const maxEvents = 100 // Recent events kept for diagnostics
+
+// event is one entry of the recent event log
+type event struct {
+	Time    float64 `json:"time"` // Game seconds since the session started
+	Message string  `json:"message"`
+}
+
+// diagnostics is a self-contained bundle for reproducing a reported issue
+type diagnostics struct {
+	Written     time.Time `json:"written"`
+	SaveVersion int       `json:"saveVersion"`
+	GoVersion   string    `json:"goVersion"`
+	Platform    string    `json:"platform"`
+	Seed        uint64    `json:"seed"` // 0 if the random source was supplied by WithRand
+	Slot        int       `json:"slot"`
+	TPS         float64   `json:"tps"`
+	State       saveData  `json:"state"`
+	Settings    Settings  `json:"settings"`
+	Events      []event   `json:"events"`
+}
+
+// WithSeed makes the game's randomness reproducible from seed
+func WithSeed(seed uint64) GameOption {
+	return func(g *Game) {
+		g.seed = seed
+		g.rng = newRand(seed)
+	}
+}
+
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// Record an event for diagnostics, dropping the oldest beyond maxEvents
+func (g *Game) logEvent(format string, args ...any) {
+	if len(g.events) >= maxEvents {
+		g.events = g.events[1:]
+	}
+	g.events = append(g.events, event{Time: g.animationTime, Message: fmt.Sprintf(format, args...)})
+}
+
+// ExportDiagnostics writes the current state, settings and recent events to path
+func (g *Game) ExportDiagnostics(path string) error {
+	d := diagnostics{
+		Written:     time.Now(),
+		SaveVersion: saveVersion,
+		GoVersion:   runtime.Version(),
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+		Seed:        g.seed,
+		Slot:        g.slot,
+		TPS:         ebiten.ActualTPS(),
+		State:       g.snapshot(),
+		Settings:    g.settings,
+		Events:      g.events,
+	}
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// Write a diagnostics bundle next to the game and report where it went
+func (g *Game) exportDiagnostics() {
+	path := fmt.Sprintf("magiclick-diagnostics-%s.json", time.Now().Format("20060102-150405"))
+	if err := g.ExportDiagnostics(path); err != nil {
+		log.Printf("failed to export diagnostics: %v", err)
+		g.diagnosticsStatus = "Diagnostics export failed"
+	} else {
+		log.Printf("wrote %s", path)
+		g.diagnosticsStatus = "Wrote " + path
+	}
+	g.diagnosticsTimer = diagnosticsStatusDuration
+}
+
+const diagnosticsStatusDuration = 5.0 // Seconds the export result stays on screen
+
+func (g *Game) drawDiagnosticsStatus(screen *ebiten.Image) {
+	if g.diagnosticsTimer <= 0 {
+		return
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(tokenShopX, 180)
+	op.ColorScale.ScaleWithColor(color.RGBA{200, 200, 255, 255})
+	text.Draw(screen, g.diagnosticsStatus, g.face(18), op)
+}
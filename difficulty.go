@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Difficulty is a preset that shapes the long-term growth curve
+type Difficulty struct {
+	name          string
+	levelExponent float64 // Rotation speed scales with level^levelExponent
+}
+
+// Built-in difficulty presets; Normal keeps the original linear scaling
+var difficulties = []Difficulty{
+	{name: "easy", levelExponent: 1.1},
+	{name: "normal", levelExponent: 1.0},
+	{name: "hard", levelExponent: 0.9},
+}
+
+const defaultDifficulty = "normal"
+
+// Look up a difficulty preset by name as used by the -difficulty flag and save files
+func parseDifficulty(name string) (Difficulty, error) {
+	for _, d := range difficulties {
+		if d.name == name {
+			return d, nil
+		}
+	}
+	return Difficulty{}, fmt.Errorf("unknown difficulty %q (want easy, normal or hard)", name)
+}
+
+// Effective level used for rotation speed under the given exponent
+func scaledLevel(level int, exponent float64) float64 {
+	return math.Pow(float64(level), exponent)
+}
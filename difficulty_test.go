@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDifficultyRelativeRates(t *testing.T) {
+	tests := []struct {
+		difficulty string
+		ratio      float64 // Speed at level 20 relative to level 10
+	}{
+		{"easy", math.Pow(2, 1.1)},
+		{"normal", 2},
+		{"hard", math.Pow(2, 0.9)},
+	}
+	for _, tt := range tests {
+		d, err := parseDifficulty(tt.difficulty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g := newIdleTestGame(t)
+		g.difficulty = d
+		g.generators[0].level = 10
+		low := g.rotationSpeed(0)
+		g.generators[0].level = 20
+		high := g.rotationSpeed(0)
+		if got := high / low; math.Abs(got-tt.ratio) > 1e-12 {
+			t.Errorf("%s: level 20 turns %vx as fast as level 10, want %vx", tt.difficulty, got, tt.ratio)
+		}
+
+		// A single level is the same under every exponent
+		g.generators[0].level = 1
+		if got, want := g.rotationSpeed(0), g.generators[0].speedPerLevel*g.tokenSpeedMultiplier()*g.overclockSpeedMultiplier(); got != want {
+			t.Errorf("%s: level 1 speed = %v, want %v", tt.difficulty, got, want)
+		}
+	}
+}
+
+func TestDifficultyOrdering(t *testing.T) {
+	// Past level 1 easier presets always turn faster
+	for _, level := range []int{2, 10, 100, 1000} {
+		easy, normal, hard := scaledLevel(level, 1.1), scaledLevel(level, 1.0), scaledLevel(level, 0.9)
+		if !(easy > normal && normal > hard) || normal != float64(level) {
+			t.Errorf("level %d: easy %v, normal %v, hard %v", level, easy, normal, hard)
+		}
+	}
+}
+
+func TestParseDifficultyUnknown(t *testing.T) {
+	if _, err := parseDifficulty("nightmare"); err == nil {
+		t.Error("unknown difficulty was accepted")
+	}
+}
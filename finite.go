@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+)
+
+// errNotFinite marks save data containing NaN or infinite numbers
+var errNotFinite = errors.New("value is not a finite number")
+
+// finite replaces a non-finite value so it can't spread through the economy.
+// NaN falls back to fallback and infinities clamp to the largest float64.
+// Each name is only reported once to avoid flooding the log every tick.
+func (g *Game) finite(name string, v, fallback float64) float64 {
+	if isFinite(v) {
+		return v
+	}
+	g.warnNonFinite(name, v)
+	return clampFinite(v, fallback)
+}
+
+// Report that name became the non-finite v, once per name
+func (g *Game) warnNonFinite(name string, v float64) {
+	if g.nonFiniteWarned[name] {
+		return
+	}
+	if g.nonFiniteWarned == nil {
+		g.nonFiniteWarned = make(map[string]bool)
+	}
+	g.nonFiniteWarned[name] = true
+	log.Printf("WARNING: %s became %v, clamping it", name, v)
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// clampFinite is finite without the warning, for values that are only displayed
+func clampFinite(v, fallback float64) float64 {
+	switch {
+	case math.IsInf(v, 1):
+		return math.MaxFloat64
+	case math.IsInf(v, -1):
+		return -math.MaxFloat64
+	case math.IsNaN(v):
+		return fallback
+	}
+	return v
+}
+
+// Clamp every stored economy value that could overflow. Each change already
+// guards its own result; this catches any path that slipped through before the
+// value is saved or multiplied further. The mana balance is a BigNumber, which
+// has no upper limit.
+func (g *Game) clampEconomy() {
+	g.lifetimeMana = g.finite("lifetimeMana", g.lifetimeMana, 0)
+	g.totalMultiplier = g.finite("totalMultiplier", g.totalMultiplier, 1)
+	g.ascensionPoints = g.finite("ascensionPoints", g.ascensionPoints, 0)
+	for i := range g.generators {
+		g.generators[i].manaMultiplier = g.finite("manaMultiplier", g.generators[i].manaMultiplier, 1)
+	}
+}
+
+// validate reports the first non-finite number in the save data
+func (d saveData) validate() error {
+	check := func(name string, v float64) error {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%s: %w", name, errNotFinite)
+		}
+		return nil
+	}
+
+	// Mana is a BigNumber, whose decoding already rejects non-finite values
+	if err := check("sharedRotationAngle", d.SharedAngle); err != nil {
+		return err
+	}
+	if err := check("lifetimeMana", d.LifetimeMana); err != nil {
+		return err
+	}
+	if err := check("runStartLifetimeMana", d.RunStart); err != nil {
+		return err
+	}
+	if err := check("playTime", d.PlayTime); err != nil {
+		return err
+	}
+	if err := check("ascensionPoints", d.AscensionPoints); err != nil {
+		return err
+	}
+	if err := check("boostRemaining", d.BoostRemaining); err != nil {
+		return err
+	}
+	if err := check("boostCooldown", d.BoostCooldown); err != nil {
+		return err
+	}
+	if err := check("overclockRemaining", d.Overclock); err != nil {
+		return err
+	}
+	if err := check("overclockCooldown", d.OverclockCool); err != nil {
+		return err
+	}
+	if err := check("surgeRemaining", d.SurgeRemaining); err != nil {
+		return err
+	}
+	for i, generator := range d.Generators {
+		if err := check(fmt.Sprintf("generators[%d].cost", i), generator.Cost); err != nil {
+			return err
+		}
+		if err := check(fmt.Sprintf("generators[%d].manaMultiplier", i), generator.ManaMultiplier); err != nil {
+			return err
+		}
+		if err := check(fmt.Sprintf("generators[%d].multiplierGain", i), generator.MultiplierGain); err != nil {
+			return err
+		}
+	}
+	for i, angle := range d.RotationAngles {
+		if err := check(fmt.Sprintf("rotationAngles[%d]", i), angle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
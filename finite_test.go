@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFinite(t *testing.T) {
+	tests := []struct {
+		v, fallback, want float64
+	}{
+		{12.5, 1, 12.5},
+		{math.NaN(), 1, 1},
+		{math.Inf(1), 1, math.MaxFloat64},
+		{math.Inf(-1), 1, -math.MaxFloat64},
+	}
+	for _, tt := range tests {
+		g := &Game{}
+		if got := g.finite("value", tt.v, tt.fallback); got != tt.want {
+			t.Errorf("finite(%v, %v) = %v, want %v", tt.v, tt.fallback, got, tt.want)
+		}
+		if warned := g.nonFiniteWarned["value"]; warned == isFinite(tt.v) {
+			t.Errorf("finite(%v) warned = %v", tt.v, warned)
+		}
+	}
+}
+
+func TestClampEconomy(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.lifetimeMana = math.Inf(1)
+	g.totalMultiplier = math.NaN()
+	g.ascensionPoints = math.NaN()
+	g.generators[0].manaMultiplier = math.Inf(1)
+	g.generators[1].manaMultiplier = math.NaN()
+	g.clampEconomy()
+
+	if g.lifetimeMana != math.MaxFloat64 || g.totalMultiplier != 1 || g.ascensionPoints != 0 {
+		t.Errorf("lifetime %v, multiplier %v, points %v after clamping", g.lifetimeMana, g.totalMultiplier, g.ascensionPoints)
+	}
+	if m0, m1 := g.generators[0].manaMultiplier, g.generators[1].manaMultiplier; m0 != math.MaxFloat64 || m1 != 1 {
+		t.Errorf("generator multipliers %v and %v after clamping", m0, m1)
+	}
+}
+
+func TestProductionWithNonFiniteMultiplier(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1)} {
+		g := newIdleTestGame(t)
+		g.generators[0].level = 1
+		g.generators[0].manaMultiplier = v
+		g.calculateManaPerSec()
+		if !isFinite(g.totalMultiplier) || g.totalMultiplier < 0 {
+			t.Errorf("multiplier %v: production = %v", v, g.totalMultiplier)
+		}
+		g.step(1)
+		if !isFinite(g.manaValue()) {
+			t.Errorf("multiplier %v: mana = %v after a step", v, g.manaValue())
+		}
+	}
+}
+
+func TestValidateNonFinite(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		set   func(d *saveData)
+	}{
+		{"NaN lifetime mana", "lifetimeMana", func(d *saveData) { d.LifetimeMana = math.NaN() }},
+		{"infinite play time", "playTime", func(d *saveData) { d.PlayTime = math.Inf(1) }},
+		{"NaN generator multiplier", "generators[1].manaMultiplier", func(d *saveData) { d.Generators[1].ManaMultiplier = math.NaN() }},
+		{"infinite generator cost", "generators[0].cost", func(d *saveData) { d.Generators[0].Cost = math.Inf(-1) }},
+		{"NaN rotation angle", "rotationAngles[2]", func(d *saveData) { d.RotationAngles[2] = math.NaN() }},
+	}
+	for _, tt := range tests {
+		data := newTestGame(t).snapshot()
+		if err := data.validate(); err != nil {
+			t.Fatalf("fresh snapshot: %v", err)
+		}
+		tt.set(&data)
+		err := data.validate()
+		if !errors.Is(err, errNotFinite) || !strings.Contains(err.Error(), tt.field) {
+			t.Errorf("%s: validate() = %v, want a non-finite %s", tt.name, err, tt.field)
+		}
+	}
+}
+
+func TestSaveGameRefusesNonFinite(t *testing.T) {
+	g := newTestGame(t)
+	g.sharedRotationAngle = math.NaN()
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := g.SaveGame(path); !errors.Is(err, errNotFinite) {
+		t.Fatalf("SaveGame() = %v, want errNotFinite", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("non-finite save was written: %v", err)
+	}
+}
+
+func TestLoadGameRejectsNonFiniteMana(t *testing.T) {
+	for _, mana := range []string{`"NaN"`, `"Inf"`, `"-Inf"`} {
+		path := filepath.Join(t.TempDir(), "save.json")
+		if err := os.WriteFile(path, []byte(`{"version": 2, "mana": `+mana+`}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		g := newTestGame(t)
+		g.setMana(5)
+		if err := g.LoadGame(path); err == nil {
+			t.Errorf("mana %s was loaded", mana)
+		}
+		if g.manaValue() != 5 {
+			t.Errorf("mana %s: a rejected save changed mana to %v", mana, g.manaValue())
+		}
+	}
+}
+
+func TestExtremeMultipliersStayFinite(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.storageLevel = 1000
+	g.timeScale = 1000
+	for i := range g.generators {
+		g.generators[i].level = maxGeneratorLevel
+		g.generators[i].manaMultiplier = math.MaxFloat64 / 2
+		g.generators[i].multiplierGain = math.MaxFloat64 / 2
+	}
+	g.ascensionPoints = math.MaxFloat64
+	g.lifetimeMana = math.MaxFloat64
+	for range 120 {
+		g.step(1.0 / 60)
+	}
+
+	if !isFinite(g.totalMultiplier) || !isFinite(g.lifetimeMana) || !isFinite(g.ascensionPoints) {
+		t.Errorf("multiplier %v, lifetime %v, points %v", g.totalMultiplier, g.lifetimeMana, g.ascensionPoints)
+	}
+	if m := g.mana.mantissa; !isFinite(m) || g.mana.Cmp(NewBigNumber(1)) < 0 {
+		t.Errorf("mana = %v", g.mana)
+	}
+	for i, generator := range g.generators {
+		if !isFinite(generator.manaMultiplier) {
+			t.Errorf("generators[%d].manaMultiplier = %v", i, generator.manaMultiplier)
+		}
+	}
+	if err := g.snapshot().validate(); err != nil {
+		t.Errorf("extreme state can't be saved: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Smallest text scale in small windows, so labels stay legible
+const minTextScale = 0.75
+
+// Windows larger than the 1920x1080 the UI is laid out for magnify the whole
+// screen instead of leaving small panels and text on a large canvas, e.g. on a
+// 4K monitor without OS scaling. Ebiten reports the window in device-independent
+// pixels, so OS display scaling is already accounted for.
+func layoutScale(outsideWidth, outsideHeight int) float64 {
+	return max(1, min(float64(outsideWidth)/screenWidth, float64(outsideHeight)/screenHeight))
+}
+
+// Text scale for the current screen: smaller windows shrink text along with the
+// center visualization, down to minTextScale
+func (g *Game) textScale() float64 {
+	return max(minTextScale, min(1, g.uiScale()))
+}
+
+// Font face of the given size at 1920x1080, scaled for the current screen.
+// Every text in the game goes through this.
+func (g *Game) face(size float64) *text.GoTextFace {
+	return &text.GoTextFace{
+		Source: g.fontSource,
+		Size:   size * g.textScale(),
+	}
+}
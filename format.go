@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// How the main mana readout is rounded, independent of the precise value
+var manaRoundings = []string{"Decimals", "Floor", "Round"}
+
+// Number formats cycled with N: suffixed (1.23M), full digits or scientific
+var numberFormats = []string{"Suffixed", "Standard", "Scientific"}
+
+// Largest value shown with all its digits in the standard format; beyond it
+// float64 has no exact digits left to show
+const maxStandardNumber = 1e15
+
+// Suffixes for each power of 1000, starting at thousands
+var numberSuffixes = []string{"K", "M", "B", "T", "Qa", "Qi", "Sx", "Sp", "Oc", "No", "Dc"}
+
+// Format a number for display: two decimals below 1000, above that three
+// significant figures with a suffix (1.23K, 45.6M), scientific past the suffixes
+func formatNumber(v float64) string {
+	abs := math.Abs(v)
+	if !isFinite(v) {
+		return fmt.Sprintf("%.2e", v)
+	}
+	if abs < 999.995 {
+		return fmt.Sprintf("%.2f", v)
+	}
+
+	// Round to three significant figures first so 999,999 becomes 1.00M, not 1000K
+	exp := int(math.Floor(math.Log10(abs)))
+	scale := math.Pow(10, float64(exp-2))
+	abs = math.Round(abs/scale) * scale
+	// Values near the largest float64 round up to +Inf
+	if !isFinite(abs) {
+		return fmt.Sprintf("%.2e", v)
+	}
+	exp = int(math.Floor(math.Log10(abs)))
+
+	tier := exp / 3
+	if tier < 1 || tier > len(numberSuffixes) {
+		return fmt.Sprintf("%.2e", v)
+	}
+	scaled := math.Copysign(abs/math.Pow(1000, float64(tier)), v)
+	switch exp % 3 {
+	case 0:
+		return fmt.Sprintf("%.2f%s", scaled, numberSuffixes[tier-1])
+	case 1:
+		return fmt.Sprintf("%.1f%s", scaled, numberSuffixes[tier-1])
+	}
+	return fmt.Sprintf("%.0f%s", scaled, numberSuffixes[tier-1])
+}
+
+// Format a number in the player's chosen number format
+func (g *Game) format(v float64) string {
+	switch g.settings.NumberFormat {
+	case "Standard":
+		return formatStandard(v)
+	case "Scientific":
+		return formatScientific(v)
+	}
+	return formatNumber(v)
+}
+
+// Format a number with all its digits and thousands separators (1,234,567),
+// two decimals below 1000 and scientific once it's too large to be exact
+func formatStandard(v float64) string {
+	abs := math.Abs(v)
+	if abs < 999.995 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.2f", v)
+	}
+	if abs >= maxStandardNumber {
+		return formatScientific(v)
+	}
+	digits := fmt.Sprintf("%.0f", abs)
+	var b strings.Builder
+	if v < 0 {
+		b.WriteByte('-')
+	}
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// Format a number in scientific notation (1.23e+06), two decimals below 1000
+func formatScientific(v float64) string {
+	if math.Abs(v) < 999.995 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprintf("%.2e", v)
+}
+
+// Cycle the number format with N
+func (g *Game) handleNumberFormatKey() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyN) {
+		g.settings.NumberFormat = nextInCycle(numberFormats, g.settings.NumberFormat)
+		g.saveSettings()
+	}
+}
+
+// Format mana for the main readout according to the rounding setting. Rounding
+// only matters below 1000, larger values are abbreviated.
+func (g *Game) formatManaReadout(v float64) string {
+	if math.Abs(v) >= 1000 {
+		return g.format(v)
+	}
+	switch g.settings.ManaRounding {
+	case "Floor":
+		return fmt.Sprintf("%.0f", math.Floor(v))
+	case "Round":
+		return fmt.Sprintf("%.0f", math.Round(v))
+	}
+	return g.format(v)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatManaReadout(t *testing.T) {
+	tests := []struct {
+		rounding string
+		v        float64
+		want     string
+	}{
+		{"Decimals", 12.345, "12.35"},
+		{"Decimals", 12.7, "12.70"},
+		{"Floor", 12.345, "12"},
+		{"Floor", 12.7, "12"},
+		{"Floor", 999.99, "999"},
+		{"Round", 12.345, "12"},
+		{"Round", 12.5, "13"},
+		{"Round", 12.7, "13"},
+		// Values from 1000 on are abbreviated the same way under every policy
+		{"Decimals", 1234.5, "1.23K"},
+		{"Floor", 1234.5, "1.23K"},
+		{"Round", 1234.5, "1.23K"},
+	}
+	for _, tt := range tests {
+		g := &Game{settings: Settings{ManaRounding: tt.rounding, NumberFormat: "Suffixed"}}
+		if got := g.formatManaReadout(tt.v); got != tt.want {
+			t.Errorf("%s: formatManaReadout(%v) = %q, want %q", tt.rounding, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		v    float64
+		want string
+	}{
+		{0, "0.00"},
+		{12.345, "12.35"},
+		{999.99, "999.99"},
+		{999.995, "1.00K"},
+		{1000, "1.00K"},
+		{1234, "1.23K"},
+		{12345, "12.3K"},
+		{123456, "123K"},
+		{999_499, "999K"},
+		{999_999, "1.00M"},
+		{1_000_000, "1.00M"},
+		{1e9, "1.00B"},
+		{4.56e12, "4.56T"},
+		{7.89e15, "7.89Qa"},
+		{1e18, "1.00Qi"},
+		{1.5e33, "1.50Dc"},
+		{999e33, "999Dc"},
+		// Past the last suffix
+		{999.999e33, "1.00e+36"},
+		{1e36, "1.00e+36"},
+		{math.MaxFloat64, "1.80e+308"},
+		{-math.MaxFloat64, "-1.80e+308"},
+		{math.Inf(1), "+Inf"},
+		{math.NaN(), "NaN"},
+		{-999.99, "-999.99"},
+		{-1234, "-1.23K"},
+		{-1_000_000, "-1.00M"},
+	}
+	for _, tt := range tests {
+		if got := formatNumber(tt.v); got != tt.want {
+			t.Errorf("formatNumber(%v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const buyRepeatDelay = 0.4 // Seconds a buy key must be held before it starts repeating
+
+// Keys that buy the generator with the same index
+var buyKeys = [...]ebiten.Key{ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4, ebiten.Key5, ebiten.Key6, ebiten.Key7, ebiten.Key8, ebiten.Key9}
+
+// Selectable repeat rates for held buy keys, in purchases per second
+var buyRepeatRates = []int{5, 10, 20}
+
+// Buy generators with the number keys, repeating while a key is held
+func (g *Game) handleBuyKeys(dt float64) {
+	interval := 1 / float64(max(1, g.settings.BuyRepeatRate))
+	for i, key := range buyKeys {
+		if i >= g.shownGenerators() {
+			break
+		}
+		if !ebiten.IsKeyPressed(key) {
+			g.buyKeyHeld[i] = 0
+			continue
+		}
+		// A whole hold counts as one purchase action for the per-action cap.
+		// The press itself buys like a click on the panel.
+		if inpututil.IsKeyJustPressed(key) {
+			g.buyKeyHeld[i] = 0
+			g.buyKeyBought[i] = g.buyBulk(i, g.panelQuantity(i))
+			g.playPurchaseSound(g.buyKeyBought[i])
+			continue
+		}
+
+		held := g.buyKeyHeld[i] + dt
+		n := repeatCount(g.buyKeyHeld[i], held, buyRepeatDelay, interval)
+		g.buyKeyBought[i] += g.buyLevels(i, n, g.buyKeyBought[i])
+		g.buyKeyHeld[i] = held
+	}
+}
+
+// Click the orb with the space bar
+func (g *Game) handleOrbKey() {
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		g.clickOrb()
+	}
+}
+
+// Number of repeats fired while a key's hold time went from prev to held seconds.
+// The first repeat fires after delay, then one every interval.
+func repeatCount(prev, held, delay, interval float64) int {
+	fired := func(t float64) int {
+		if t < delay {
+			return 0
+		}
+		return int((t-delay)/interval) + 1
+	}
+	return fired(held) - fired(prev)
+}
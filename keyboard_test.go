@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestRepeatCount(t *testing.T) {
+	tests := []struct {
+		name            string
+		prev, held      float64
+		delay, interval float64
+		want            int
+	}{
+		{"before the delay", 0, 0.3, 0.4, 0.1, 0},
+		{"reaching the delay", 0.3, 0.4, 0.4, 0.1, 1},
+		{"crossing the delay", 0.39, 0.41, 0.4, 0.1, 1},
+		{"between repeats", 0.41, 0.45, 0.4, 0.1, 0},
+		{"several intervals in one tick", 0.4, 0.75, 0.4, 0.1, 3},
+		{"whole hold from zero", 0, 1.05, 0.4, 0.1, 7},
+	}
+	for _, tt := range tests {
+		if got := repeatCount(tt.prev, tt.held, tt.delay, tt.interval); got != tt.want {
+			t.Errorf("%s: repeatCount(%v, %v) = %d, want %d", tt.name, tt.prev, tt.held, got, tt.want)
+		}
+	}
+}
+
+func TestRepeatCountPerRate(t *testing.T) {
+	// A key held for just under a second fires at the delay and then once per interval
+	want := map[int]int{5: 3, 10: 6, 20: 12}
+	for _, rate := range buyRepeatRates {
+		if got := repeatCount(0, 0.97, buyRepeatDelay, 1/float64(rate)); got != want[rate] {
+			t.Errorf("%d/s: %d repeats in 0.97s, want %d", rate, got, want[rate])
+		}
+	}
+}
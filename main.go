@@ -2,10 +2,15 @@ package main
 
 import (
 	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
 	"math"
+	"math/rand/v2"
+	"os"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
@@ -18,21 +23,149 @@ const (
 	screenWidth  = 1920
 	screenHeight = 1080
 	orbSize      = 100
+	maxOrbitRadius = 300 // Outermost orbit at zoom 1, however many generators there are
+	
+	maxGeneratorLevel = 100
+	
+	unlockAnimationDuration = 1.0 // Seconds a newly unlocked orbit takes to appear
+	rotationMultiplierGain  = 0.01 // Multiplier added by each full rotation before upgrades
 )
 
 type Game struct {
-	mana            float64     // Mana with decimal precision
-	manaPerSec      int64       // Stored as hundredths (e.g. 150 = 1.50/sec)
+	mana            BigNumber   // Mana with decimal precision, see setMana
+	manaPerSec      BigNumber   // Production rate, totalMultiplier without float64's limit
 	orbX            float64
 	orbY            float64
 	orbClicked      bool
 	clickAnimation  int
+	floatTexts      []floatText // "+N" labels from recent orb clicks
+	touchIDs        []ebiten.TouchID // Reused buffer for newly pressed touches
+	shopScroll      float64    // Pixels the shop list is scrolled down
+	arcVertices     []ebiten.Vertex  // Reused buffers for stroking arcs
+	arcIndices      []uint16
+	status          *statusServer // Serves the game state over HTTP when started with -status
+	timeScale       float64       // Simulation speed, above 1 while fast forwarding (debug)
+	undo            purchaseUndo  // Last purchase, revertible for a few seconds
 	generators      []Generator
-	tickCounter     int
+	lastTick        time.Time  // Wall clock time of the previous tick
+	lastAnimationTick time.Time // Wall clock time animations last advanced to
 	animationTime   float64
 	rotationAngles  []float64  // Rotation angles for center indicators
 	totalMultiplier float64    // Total multiplicative effect
 	fontSource      *text.GoTextFaceSource
+	slot            int        // Active save slot (profile)
+	savePath        string     // Where progress is saved on exit
+	timerMode       timerMode  // Economy variant for rotation timers
+	difficulty      Difficulty // Growth curve preset
+	sharedRotationAngle float64 // Rotation angle used by timerModeShared
+	settings        Settings   // Player preferences
+	settingsPath    string
+	config          Config     // Generator balance and starting mana
+	synergies       []synergy  // Bonuses between generators, from the config
+	configPath      string
+	optionsOpen     bool       // Options menu is shown
+	prestigeConfirmOpen bool   // The prestige confirmation dialog is shown
+	titleTimer      float64    // Seconds until the window title is refreshed
+	windowTitle     string     // Title currently shown on the window
+	rng             *rand.Rand // Source for every random roll in the game
+	multiplierHistories []multiplierHistory // Recent manaMultiplier samples per generator
+	historyTimer    float64    // Seconds since the last history sample
+	retirementTokens     int   // Unspent tokens from retired generators
+	tokenProductionLevel int   // Tokens spent on the production bonus
+	tokenSpeedLevel      int   // Tokens spent on the rotation speed bonus
+	tokenDiscountLevel   int   // Tokens spent on the generator cost discount
+	critChanceLevel      int   // Upgrades bought for the lucky click chance
+	critMultiplierLevel  int   // Upgrades bought for the lucky click reward
+	boostRemaining  float64    // Seconds left on the active "boost all" ultimate
+	boostCooldown   float64    // Seconds until the boost can be triggered again
+	overclockUntil         float64 // animationTime the doubled rotation speed ends at
+	overclockCooldownUntil float64 // animationTime the overclock can be triggered again at
+	surgeRemaining  float64    // Seconds left on the running mana surge
+	surgeRollTimer  float64    // Seconds since the last roll for a surge
+	buyKeyHeld      [len(buyKeys)]float64 // Seconds each buy key has been held
+	buyKeyBought    [len(buyKeys)]int     // Levels bought during the current hold of each buy key
+	nonFiniteWarned map[string]bool       // Values already reported as NaN/Inf
+	unlockAnimations []float64 // Seconds left on each generator's unlock reveal
+	redeemedCodes   []string   // Promo codes already used in this save
+	promoEditing    bool       // A promo code is being typed in the options menu
+	promoInput      string
+	promoMessage    string     // Outcome of the last redemption attempt
+	baselineProduction float64 // Minimum mana/sec while the trickle is enabled
+	trickleLevel    int        // Baseline trickle upgrades bought
+	trickleApplied  bool       // The baseline is currently setting the production rate
+	confirmingSlotDelete bool  // The delete slot option was clicked once
+	confirmingReset      bool  // The reset game option was clicked once
+	resetConfirmTimer    float64 // Seconds until an armed reset disarms
+	debug           bool       // Debug actions enabled with -debug
+	bench           benchmark  // Debug stress test state
+	gifEnabled      bool       // GIF recording enabled with -gif
+	recorder        gifRecorder
+	orbitPanX       float64    // Pan offset of the orbit view in screen pixels
+	orbitPanY       float64
+	orbitZoom       float64    // Zoom of the orbit view, 1 is the default size
+	orbitDragging   bool       // The orbit view is being dragged
+	dragLastX       int        // Cursor position at the previous drag tick
+	dragLastY       int
+	sounds          *sounds    // Sound effects, nil without audio
+	audioDisabled   bool       // Skip setting up audio, see WithoutAudio
+	width           int        // Screen size from the last Layout
+	height          int
+	seed            uint64     // Seed of rng, 0 if it was supplied by WithRand
+	events          []event    // Recent events for diagnostics, oldest first
+	diagnosticsStatus string   // Result of the last diagnostics export
+	diagnosticsTimer  float64  // Seconds the result stays on screen
+	orbClicks       int        // Orb clicks counted this session
+	orbClickValue   float64    // Mana added by each counted orb click
+	lastOrbClick    float64    // Game time of the last counted orb click
+	lastPanelClick  int        // Generator panel clicked last, -1 after a double-click
+	lastPanelClickAt float64   // Game time of that click
+	storageLevel    int        // Storage upgrades bought, each multiplying the mana cap
+	economyPaused   bool       // Production and purchases are frozen while animations keep running
+	perfOverlay     bool       // FPS/TPS debug overlay is shown, toggled with F3
+	paused          bool       // Everything is frozen, animations included, until P is pressed again
+	dailyDate       string     // Date of the daily challenge being played, empty otherwise
+	autosaveInterval int       // Seconds between autosaves, 0 disables them
+	autosaveTimer   float64    // Seconds since the last autosave
+	ascensionPoints float64    // Permanent points earned by prestiging
+	prestiges       int        // Prestiges so far, unlocking gated generators
+	buyQuantity     int        // Levels bought per panel click, buyMax for as many as affordable
+	panelQuantities []int      // Per-generator buy quantity picked with the mouse wheel, 0 follows buyQuantity
+	affordable      []affordableCount // Per-generator "Can buy" counts shown on the panels
+	lifetimeMana    float64    // All mana ever earned, kept across prestiges
+	runStartLifetime float64   // lifetimeMana when the current run started
+	totalClicks     int64      // Orb and generator panel clicks
+	playTime        float64    // Seconds played across sessions
+}
+
+const baseWindowTitle = "Magic Click - Mana Generator"
+
+// timerMode selects how generators advance their rotation timers
+type timerMode int
+
+const (
+	timerModeIndependent timerMode = iota // Each generator rotates at its own speed (default)
+	timerModeShared                       // All generators share one timer and gain together
+)
+
+// Economy variants cycled in the options menu
+var timerModes = []timerMode{timerModeIndependent, timerModeShared}
+
+// Parse a timer mode name as used by the -variant flag and save files
+func parseTimerMode(name string) (timerMode, error) {
+	switch name {
+	case "independent":
+		return timerModeIndependent, nil
+	case "shared":
+		return timerModeShared, nil
+	}
+	return 0, fmt.Errorf("unknown variant %q (want independent or shared)", name)
+}
+
+func (m timerMode) String() string {
+	if m == timerModeShared {
+		return "shared"
+	}
+	return "independent"
 }
 
 type Generator struct {
@@ -43,28 +176,109 @@ type Generator struct {
 	description    string
 	timer          int      // Individual timer for this generator
 	manaMultiplier float64  // Accumulated mana multiplier
+	retired        bool     // Traded for a retirement token, no longer in play
+	multiplierGain float64  // Multiplier added by each full rotation
+	upgradeLevel   int      // Rotation gain upgrades bought
+	costScaling    float64  // Cost growth per level
+	minPrestige    int      // Prestiges needed before the generator shows up
+	enabled        bool     // Switched on by the player, disabled generators stand still
+}
+
+// Whether production, multiplier gains and purchases are on hold. Besides an explicit
+// soft pause this covers the options menu, so reading it doesn't cost progress.
+func (g *Game) economyFrozen() bool {
+	return g.paused || g.economyPaused || g.optionsOpen
+}
+
+// A generator takes part in rotation and production once bought and until retired,
+// unless the player disabled it
+func (gen Generator) active() bool {
+	return gen.owned() && gen.enabled
+}
+
+// Bought and not retired, whether or not it's enabled
+func (gen Generator) owned() bool {
+	return gen.level > 0 && !gen.retired
+}
+
+// Factor the generator multiplies production by, neutral while disabled
+func (gen Generator) productionFactor() float64 {
+	if !gen.enabled {
+		return 1
+	}
+	return gen.manaMultiplier
+}
+
+// GameOption customizes a Game created by NewGame
+type GameOption func(*Game)
+
+// WithRand makes the game draw all randomness from r, e.g. a fixed seed or a
+// scripted sequence in tests. r's seed can't be known, so the game reports seed 0.
+func WithRand(r *rand.Rand) GameOption {
+	return func(g *Game) {
+		g.rng = r
+		g.seed = 0
+	}
+}
+
+func NewGame(opts ...GameOption) *Game {
+	return newGameInSlot(loadActiveSlot(), opts...)
 }
 
-func NewGame() *Game {
+// Create a game backed by the save and settings files of a save slot
+func newGameInSlot(slot int, opts ...GameOption) *Game {
+	savePath, settingsPath := slotPaths(slot)
+	return newGameWithPaths(slot, savePath, settingsPath, opts...)
+}
+
+// Create a game backed by the given save and settings files
+func newGameWithPaths(slot int, savePath, settingsPath string, opts ...GameOption) *Game {
 	// Load font source from embedded font
 	s, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
 	if err != nil {
 		log.Fatal(err)
 	}
 	
+	difficulty, _ := parseDifficulty(defaultDifficulty)
+	seed := rand.Uint64()
 	g := &Game{
-		mana:         0,
-		manaPerSec:   0,
 		orbX:         screenWidth/2 - orbSize/2,
 		orbY:         screenHeight/2 - orbSize/2,
-		generators: []Generator{
-			{"Mana Crystal", 3.0, 0.1, 5, "Basic mana generation crystal", 0, 1.0},
-			{"Arcane Tower", 50.0, 0.08, 0, "Mystical mana channeling tower", 0, 1.0},  
-			{"Ley Line Node", 250.0, 0.05, 0, "Powerful magical energy nexus", 0, 1.0},
-			{"Elder Artifact", 1000.0, 0.02, 0, "Ancient relic of immense power", 0, 1.0},
-		},
-		rotationAngles: make([]float64, 4),
+		baselineProduction: baseTrickle,
 		fontSource:     s,
+		slot:           slot,
+		difficulty:     difficulty,
+		orbitZoom:      1,
+		width:          screenWidth,
+		height:         screenHeight,
+		lastPanelClick: -1,
+		buyQuantity:    1,
+		timeScale:      1,
+		orbClickValue:  baseOrbClickValue,
+		autosaveInterval: defaultAutosaveInterval,
+		savePath:       savePath,
+		settingsPath:   settingsPath,
+		configPath:     defaultConfigPath,
+		windowTitle:    baseWindowTitle,
+		seed:           seed,
+		rng:            newRand(seed),
+	}
+	g.loadConfig()
+	g.resetGenerators()
+	g.loadSettings()
+	g.setMana(g.config.StartingMana)
+	
+	// Restore previous progress if a save exists
+	g.loadSave()
+	
+	// Options override anything loaded from disk
+	for _, opt := range opts {
+		opt(g)
+	}
+	
+	// The audio context is process-wide, so only set it up when sound is wanted
+	if !g.audioDisabled {
+		g.sounds = newSounds()
 	}
 	
 	// Calculate initial mana per second using multiplicative system
@@ -73,24 +287,164 @@ func NewGame() *Game {
 	return g
 }
 
+// Load the save at savePath, never touching a save written by a newer build
+func (g *Game) loadSave() {
+	err := g.LoadGame(g.savePath)
+	if errors.Is(err, ErrSaveTooNew) {
+		// Keep the newer save intact and continue on a separate file instead
+		fallback := downgradeSavePath(g.savePath)
+		log.Printf("WARNING: %v", err)
+		log.Printf("WARNING: the newer save will not be modified; progress is saved to %s instead", fallback)
+		g.savePath = fallback
+		err = g.LoadGame(g.savePath)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("failed to load save: %v", err)
+	}
+}
+
+// Mana produced at rate per second over dt seconds. Summed over ticks this pays
+// the same as one payout of rate per second, just without the jumps.
+func accruedMana(rate BigNumber, dt float64) BigNumber {
+	if rate.sign() <= 0 || dt <= 0 {
+		return BigNumber{}
+	}
+	return rate.Mul(NewBigNumber(dt))
+}
+
+// Product of all mana multipliers and global bonuses, before the trickle floor.
+// Several huge factors multiply past float64's range even when each is finite.
+func (g *Game) rawProduction() BigNumber {
+	total := NewBigNumber(1)
+	for _, factor := range g.ProductionBreakdown() {
+		total = total.Mul(NewBigNumber(factor.Value))
+	}
+	return total
+}
+
+// rawProduction as a float64, clamped to its range
+func (g *Game) rawProductionMultiplier() float64 {
+	return clampFinite(g.rawProduction().Float64(), 1)
+}
+
 // Calculate mana per second using mana multiplier system
 func (g *Game) calculateManaPerSec() {
-	// Calculate the product of all mana multipliers
-	g.totalMultiplier = 1.0
+	rate := softCapBig(g.rawProduction(), g.config.SoftCap)
 	
-	for _, generator := range g.generators {
-		g.totalMultiplier *= generator.manaMultiplier
+	// Production never drops below the baseline trickle
+	g.trickleApplied = g.settings.BaselineTrickle && rate.Cmp(NewBigNumber(g.baselineProduction)) < 0
+	if g.trickleApplied {
+		rate = NewBigNumber(g.baselineProduction)
 	}
 	
-	// Convert to mana per second (keep full precision)
-	g.manaPerSec = int64(g.totalMultiplier * 100 + 0.5) // Store as hundredths
+	// Past float64's range only manaPerSec keeps the exact rate
+	g.manaPerSec = rate
+	g.totalMultiplier = clampFinite(rate.Float64(), 1)
 }
 
 func (g *Game) Update() error {
-	// Handle mouse clicks for generators only
+	dt := g.tickDelta()
+	animationDt := g.animationDelta()
+	g.applyBackgroundCatchUp()
+	g.publishStatus()
+	
+	// Typing a promo code takes over the keyboard
+	typing := g.promoEditing
+	if typing {
+		g.updatePromoInput()
+	}
+	
+	// While paused only the pause and overlay keys are handled and the simulation
+	// doesn't advance
+	if !typing {
+		g.handlePauseKey()
+		g.handlePerfOverlayKey()
+	}
+	if g.paused {
+		return nil
+	}
+	if g.blurPaused() {
+		return nil
+	}
+	
+	// Visual effects follow the wall clock rather than game time
+	g.animationTime += animationDt
+	g.updateFloatTexts(animationDt)
+	
+	// Toggle the options menu
+	if inpututil.IsKeyJustPressed(ebiten.KeyO) && !typing {
+		g.optionsOpen = !g.optionsOpen
+		g.promoMessage = ""
+	}
+	
+	// Trigger the "boost all" ultimate
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) && !g.optionsOpen {
+		g.activateBoost()
+	}
+	
+	// Debug stress test
+	if g.debug && inpututil.IsKeyJustPressed(ebiten.KeyF9) && !typing {
+		g.startBenchmark()
+	}
+	g.updateBenchmark(dt)
+	if !typing {
+		g.handleTimeScaleKey()
+	}
+	
+	// Record a GIF of the session
+	if g.gifEnabled && inpututil.IsKeyJustPressed(ebiten.KeyF10) && !typing {
+		g.toggleRecording()
+	}
+	g.updateRecorder(dt)
+	
+	// Export a diagnostics bundle for bug reports
+	if inpututil.IsKeyJustPressed(ebiten.KeyF8) && !typing {
+		g.exportDiagnostics()
+	}
+	g.diagnosticsTimer = max(0, g.diagnosticsTimer-dt)
+	
+	// Switch between window and fullscreen, the layout follows the new size
+	if !typing {
+		g.handleFullscreenKey()
+	}
+	g.updateResetConfirm(dt)
+	g.updatePrestigeConfirm()
+	g.updateUndo(dt)
+	g.trackWindowSize()
+	
+	// Buy generators with the number keys, click the orb with space, mute with M,
+	// cycle themes with T and number formats with N, and undo a purchase with Ctrl+Z
+	if !g.optionsOpen && !g.prestigeConfirmOpen {
+		g.handleBuyKeys(dt)
+		g.handleUndoKey()
+		g.handleOrbKey()
+		g.handleMuteKey()
+		g.handleThemeKey()
+		g.handleNumberFormatKey()
+	}
+	
+	// Handle mouse clicks and taps for the options menu, buttons, generators and the orb
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		x, y := ebiten.CursorPosition()
-		g.handleGeneratorClicks(x, y)
+		
+		// Dragging anywhere that isn't a button, panel or the orb pans the orbit view
+		if !g.handleTapAt(x, y) {
+			g.startOrbitDrag(x, y)
+		}
+	}
+	g.handleTouches()
+	if !g.optionsOpen && !g.prestigeConfirmOpen {
+		g.updateShopScroll()
+		g.updatePanelQuantityWheel()
+		g.updateOrbitView()
+	}
+	
+	// Right-clicking a maxed generator retires it for a token
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) && !g.optionsOpen {
+		x, y := ebiten.CursorPosition()
+		if i := g.generatorAt(x, y); i >= 0 {
+			g.retireGenerator(i)
+		}
 	}
 	
 	// Handle orb click animation (visual effect only)
@@ -101,272 +455,658 @@ func (g *Game) Update() error {
 		g.orbClicked = false
 	}
 	
-	// Update animation time for visual effects
-	g.animationTime += 0.016 // Approximately 1/60th of a second
+	g.step(dt)
+	g.updateWindowTitle(dt)
+	g.updateAutosave(dt)
 	
-	// Update mana production using mana multiplier system
-	g.tickCounter++
-	if g.tickCounter >= 60 {
-		// Recalculate and produce mana every second
-		g.calculateManaPerSec()
-		if g.totalMultiplier > 0 {
-			// Add mana with full precision
-			g.mana += g.totalMultiplier
-		}
-		g.tickCounter = 0
+	return nil
+}
+
+// Advance the simulation by dt seconds: production, rotations, timers and unlock
+// reveals. It reads no input and draws nothing, so it can run headless.
+func (g *Game) advance(dt float64) {
+	g.playTime += dt
+	
+	// Add a share of the rate step calculated, with full precision
+	if !g.economyFrozen() {
+		g.earnMana(accruedMana(g.manaPerSec, dt))
+	}
+	
+	if !g.economyFrozen() {
+		g.updateBoost(dt)
+		g.updateSurge(dt)
+	}
+	
+	// Advance unlock reveals
+	for i := range g.unlockAnimations {
+		g.unlockAnimations[i] = max(0, g.unlockAnimations[i]-dt)
+	}
+	
+	// Sample multipliers for the panel sparklines
+	g.historyTimer += dt
+	if g.historyTimer >= historyInterval {
+		g.sampleMultiplierHistory()
+		g.historyTimer -= historyInterval
 	}
 	
 	// Update rotation angles and accumulate mana multipliers
+	switch g.timerMode {
+	case timerModeShared:
+		g.updateSharedRotation(dt)
+	default:
+		g.updateIndependentRotations(dt)
+	}
+	
+	g.clampEconomy()
+}
+
+// Refresh the window title with live stats, throttled so the taskbar isn't updated every tick
+func (g *Game) updateWindowTitle(dt float64) {
+	g.titleTimer -= dt
+	if g.titleTimer > 0 {
+		return
+	}
+	g.titleTimer = float64(g.settings.TitleInterval)
+	
+	title := baseWindowTitle
+	switch g.settings.TitleMode {
+	case "Mana":
+		title = fmt.Sprintf("%s mana - Magic Click", g.formatMana())
+	case "Mana/sec":
+		title = fmt.Sprintf("%s/sec - Magic Click", g.format(g.totalMultiplier))
+	case "Both":
+		title = fmt.Sprintf("%s mana (%s/sec) - Magic Click", g.formatMana(), g.format(g.totalMultiplier))
+	}
+	if title != g.windowTitle {
+		ebiten.SetWindowTitle(title)
+		g.windowTitle = title
+	}
+}
+
+// Rotations per second of generator i on its own timer (level * speedPerLevel,
+// with level raised to the difficulty's exponent)
+func (g *Game) rotationSpeed(i int) float64 {
+	level := scaledLevel(g.generators[i].level, g.difficulty.levelExponent)
+	return g.generators[i].speedPerLevel * level * g.tokenSpeedMultiplier() * g.overclockSpeedMultiplier()
+}
+
+// Rotations per second of the shared timer, the average speed of active generators
+func (g *Game) sharedRotationSpeed() float64 {
+	totalSpeed := 0.0
+	active := 0
 	for i := range g.generators {
-		if g.generators[i].level > 0 {
-			// Calculate individual generator rotation speed (level * speedPerLevel)
-			totalSpeed := g.generators[i].speedPerLevel * float64(g.generators[i].level)
-			
+		if g.generators[i].active() {
+			totalSpeed += g.rotationSpeed(i)
+			active++
+		}
+	}
+	if active == 0 {
+		return 0
+	}
+	return totalSpeed / float64(active)
+}
+
+// Seconds until generator i completes its next rotation and gains multiplier,
+// false if it isn't rotating
+func (g *Game) nextGainIn(i int) (float64, bool) {
+	if !g.generators[i].active() {
+		return 0, false
+	}
+	speed := g.rotationSpeed(i)
+	if g.timerMode == timerModeShared {
+		speed = g.sharedRotationSpeed()
+	}
+	return timeToNextGain(g.rotationAngles[i], speed)
+}
+
+// Seconds for an indicator at angle (radians) to reach 2π when turning at
+// rotationsPerSec, false if it doesn't move
+func timeToNextGain(angle, rotationsPerSec float64) (float64, bool) {
+	if rotationsPerSec <= 0 {
+		return 0, false
+	}
+	return (2*math.Pi - angle) / (rotationsPerSec * 2 * math.Pi), true
+}
+
+// Add the multiplier gain of the given number of completed rotations to generator i
+func (g *Game) addRotationGains(i int, rotations float64) {
+	// Indicators keep turning during a soft pause, they just don't pay out
+	if g.economyFrozen() {
+		return
+	}
+	generator := &g.generators[i]
+	generator.manaMultiplier = g.finite("manaMultiplier", generator.manaMultiplier+generator.multiplierGain*rotations, generator.manaMultiplier)
+}
+
+// Mana/sec generator i's next rotation adds to production, 0 if it isn't rotating
+func (g *Game) manaPerRotation(i int) float64 {
+	if !g.generators[i].active() {
+		return 0
+	}
+	floor := 0.0
+	if g.settings.BaselineTrickle {
+		floor = g.baselineProduction
+	}
+	generator := g.generators[i]
+	return clampFinite(rotationProductionGain(g.rawProductionMultiplier(), generator.manaMultiplier, generator.multiplierGain, floor, g.config.SoftCap), 0)
+}
+
+// Production increase from raising one factor of raw by gain.
+// raw is the total before the soft cap at threshold and the floor are applied;
+// production never drops below floor.
+func rotationProductionGain(raw, multiplier, gain, floor, threshold float64) float64 {
+	if multiplier == 0 {
+		return 0
+	}
+	after := raw / multiplier * (multiplier + gain)
+	return max(softCap(after, threshold), floor) - max(softCap(raw, threshold), floor)
+}
+
+// Advance each generator on its own rotation timer
+func (g *Game) updateIndependentRotations(dt float64) {
+	for i := range g.generators {
+		if g.generators[i].active() {
 			// Update rotation angle for visual indicator (speed 1 = 1 rotation per second)
-			rotationSpeed := totalSpeed * 2 * math.Pi / 60.0 // radians per tick
+			rotationSpeed := g.rotationSpeed(i) * 2 * math.Pi * dt // radians this tick
 			oldAngle := g.rotationAngles[i]
 			g.rotationAngles[i] += rotationSpeed
 			
-			// Check if completed a full rotation (crossed 2π boundary)
-			if oldAngle < 2*math.Pi && g.rotationAngles[i] >= 2*math.Pi {
-				// Completed a full rotation, add 0.01 to mana multiplier
-				g.generators[i].manaMultiplier += 0.01
+			// A fast generator can pass several 2π boundaries in one tick, each
+			// completed rotation adds 0.01 to the mana multiplier
+			if rotations := completedRotations(oldAngle, g.rotationAngles[i]); rotations > 0 {
+				g.addRotationGains(i, rotations)
 			}
-			
-			// Reset angle if it exceeds 2π
-			if g.rotationAngles[i] >= 2*math.Pi {
-				g.rotationAngles[i] -= 2*math.Pi
+			g.rotationAngles[i] = math.Mod(g.rotationAngles[i], 2*math.Pi)
+		}
+	}
+}
+
+// Number of 2π boundaries crossed going from oldAngle to newAngle
+func completedRotations(oldAngle, newAngle float64) float64 {
+	return math.Floor(newAngle/(2*math.Pi)) - math.Floor(oldAngle/(2*math.Pi))
+}
+
+// Advance all active generators on one shared timer so their gains are synchronized.
+// The timer runs at the average speed of the active generators, which keeps the
+// overall rate of multiplier gains equal to the independent mode.
+func (g *Game) updateSharedRotation(dt float64) {
+	speed := g.sharedRotationSpeed()
+	if speed == 0 {
+		return
+	}
+	
+	rotationSpeed := speed * 2 * math.Pi * dt // radians this tick
+	oldAngle := g.sharedRotationAngle
+	g.sharedRotationAngle += rotationSpeed
+	
+	// Completed full rotations, every active generator gains together
+	if rotations := completedRotations(oldAngle, g.sharedRotationAngle); rotations > 0 {
+		for i := range g.generators {
+			if g.generators[i].active() {
+				g.addRotationGains(i, rotations)
 			}
 		}
 	}
+	g.sharedRotationAngle = math.Mod(g.sharedRotationAngle, 2*math.Pi)
 	
-	return nil
+	// Indicators of active generators all follow the shared timer
+	for i := range g.generators {
+		if g.generators[i].active() {
+			g.rotationAngles[i] = g.sharedRotationAngle
+		}
+	}
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
-	// Clear screen with dark blue background
-	screen.Fill(color.RGBA{25, 25, 50, 255})
+	// Clear screen with the theme's background
+	theme := g.theme()
+	screen.Fill(theme.background)
 	
 	// Draw game stats with large font
-	manaText := fmt.Sprintf("Mana: %.2f", g.mana)
 	op := &text.DrawOptions{}
 	op.GeoM.Translate(20, 50)
-	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
-	text.Draw(screen, manaText, &text.GoTextFace{
-		Source: g.fontSource,
-		Size:   32, // Large font size
-	}, op)
+	manaColor := theme.text
+	if g.atManaCap() {
+		manaColor = color.RGBA{255, 170, 80, 255} // Full storage wastes production
+	}
+	op.ColorScale.ScaleWithColor(manaColor)
+	text.Draw(screen, g.manaText(), g.manaFace(), op)
+	g.drawStorageButton(screen)
 	
 	// Build multiplier calculation string
 	multiplierStr := ""
-	for i, generator := range g.generators {
-		if i > 0 {
+	for _, generator := range g.generators {
+		if generator.retired {
+			continue
+		}
+		if multiplierStr != "" {
 			multiplierStr += " x "
 		}
-		multiplierStr += fmt.Sprintf("%.2f", generator.manaMultiplier)
+		multiplierStr += fmt.Sprintf("%.2f", generator.productionFactor())
+	}
+	if synergy := g.totalSynergyMultiplier(); synergy > 1 {
+		multiplierStr += fmt.Sprintf(" x %.2f", synergy)
 	}
-	multiplierStr += fmt.Sprintf(" = %.2f/sec", g.totalMultiplier)
+	if g.tokenProductionLevel > 0 {
+		multiplierStr += fmt.Sprintf(" x %.2f", g.tokenProductionMultiplier())
+	}
+	if g.ascensionPoints > 0 {
+		multiplierStr += fmt.Sprintf(" x %.2f", g.ascensionMultiplier())
+	}
+	if g.boostRemaining > 0 {
+		multiplierStr += fmt.Sprintf(" x %.2f", g.boostProductionMultiplier())
+	}
+	if g.surgeRemaining > 0 {
+		multiplierStr += fmt.Sprintf(" x %.2f", g.surgeMultiplier())
+	}
+	if g.trickleApplied {
+		multiplierStr += fmt.Sprintf(" -> baseline %s", g.format(g.baselineProduction))
+	}
+	multiplierStr += fmt.Sprintf(" = %s/sec", g.format(g.totalMultiplier))
 	
 	op2 := &text.DrawOptions{}
-	op2.GeoM.Translate(20, 100)
-	op2.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
-	text.Draw(screen, multiplierStr, &text.GoTextFace{
-		Source: g.fontSource,
-		Size:   24, // Medium font size
-	}, op2)
-	
-	// Draw circular generators visualization (now centered)
+	op2.GeoM.Translate(multiplierLineX, multiplierLineY)
+	op2.ColorScale.ScaleWithColor(theme.text)
+	text.Draw(screen, multiplierStr, g.multiplierLineFace(), op2) // Medium font size
+	
+	g.drawStats(screen)
+	
+	// Draw the orb and the circular generators visualization around it
+	g.drawOrb(screen)
 	g.drawCircularGenerators(screen)
+	g.drawFloatTexts(screen)
+	
+	g.drawTokenShop(screen)
+	g.drawPrestige(screen)
+	g.drawBuyToggle(screen)
+	g.drawUndoHint(screen)
+	g.drawCritButtons(screen)
+	g.drawUpgrades(screen)
+	g.drawToggles(screen)
+	g.drawBoost(screen)
+	g.drawOverclock(screen)
+	g.drawTrickleButton(screen)
+	g.drawViewReset(screen)
+	
+	if g.gifEnabled {
+		g.captureFrame(screen)
+		g.drawRecorder(screen)
+	}
+	g.drawDiagnosticsStatus(screen)
+	g.drawDailyChallenge(screen)
+	g.drawSurgeBanner(screen)
+	
+	// Hovering the multiplier line explains every factor
+	if !g.optionsOpen && g.overMultiplierLine(multiplierStr) {
+		g.drawProductionBreakdown(screen)
+	}
+	if !g.optionsOpen {
+		g.drawGeneratorTooltip(screen)
+	}
+	
+	if g.debug {
+		g.recordBenchmarkFrame()
+		g.drawBenchmark(screen)
+		g.drawTimeScale(screen)
+	}
 	
+	if g.optionsOpen {
+		g.drawOptions(screen)
+	}
+	if g.prestigeConfirmOpen {
+		g.drawPrestigeConfirm(screen)
+	}
+	if g.paused {
+		g.drawPauseOverlay(screen)
+	}
+	g.drawPerfOverlay(screen)
 }
 
+// Lay out for the actual window size, magnified on windows larger than 1920x1080
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return screenWidth, screenHeight
+	scale := layoutScale(outsideWidth, outsideHeight)
+	g.width, g.height = int(float64(outsideWidth)/scale), int(float64(outsideHeight)/scale)
+	size := g.orbDiameter()
+	g.orbX = float64(g.width)/2 - size/2
+	g.orbY = float64(g.height)/2 - size/2
+	return g.width, g.height
+}
+
+// Scale of the center visualization, 1 at the original 1920x1080 screen
+func (g *Game) uiScale() float64 {
+	return float64(min(g.width, g.height)) / screenHeight
+}
+
+func (g *Game) orbDiameter() float64 {
+	return orbSize * g.uiScale()
 }
 
 func (g *Game) isMouseOverOrb(x, y float64) bool {
-	centerX := g.orbX + orbSize/2
-	centerY := g.orbY + orbSize/2
+	radius := g.orbDiameter() / 2 * g.orbitZoom
+	centerX, centerY := g.orbCenter()
 	dx := x - centerX
 	dy := y - centerY
-	return dx*dx+dy*dy <= (orbSize/2)*(orbSize/2)
+	return dx*dx+dy*dy <= radius*radius
 }
 
 func (g *Game) drawCircularGenerators(screen *ebiten.Image) {
-	centerX := float32(screenWidth / 2)
-	centerY := float32(screenHeight / 2)
+	centerX := float32(g.width / 2)
+	centerY := float32(g.height / 2)
+	theme := g.theme()
 	
+	cursorX, cursorY := ebiten.CursorPosition()
+	hovered := g.generatorAt(cursorX, cursorY)
 	
-	for i, generator := range g.generators {
+	// The shop list replaces the corner panels
+	if g.shopListShown() {
+		g.drawShop(screen)
+		g.drawCenterProductionStatus(screen, centerX, centerY)
+		return
+	}
+	
+	for i, generator := range g.generators[:g.shownGenerators()] {
 		// Draw generator info in corners (scaled positions)
-		var textX, textY int
-		switch i {
-		case 0: // Top left
-			textX = 30
-			textY = 120
-		case 1: // Top right
-			textX = screenWidth - 400
-			textY = 120
-		case 2: // Bottom left
-			textX = 30
-			textY = screenHeight - 200
-		case 3: // Bottom right
-			textX = screenWidth - 400
-			textY = screenHeight - 200
+		textX, textY, panelW, panelH := g.generatorRect(i)
+		
+		// Highlight the panel under the cursor while it can still be bought
+		if i == hovered && !g.optionsOpen && !generator.retired && generator.level < maxGeneratorLevel {
+			vector.DrawFilledRect(screen, float32(textX), float32(textY), float32(panelW), float32(panelH), color.RGBA{255, 255, 255, 20}, false)
 		}
 		
 		// Calculate current total speed
-		currentSpeed := generator.speedPerLevel * float64(generator.level)
+		currentSpeed := g.rotationSpeed(i)
 		
 		// Draw generator info with large font
 		nameText := fmt.Sprintf("%s: Lv%d", generator.name, generator.level)
-		costText := fmt.Sprintf("Cost: %.2f (+%.2f speed)", generator.cost, generator.speedPerLevel)
-		speedText := fmt.Sprintf("Speed: %.2f", currentSpeed)
+		buyLabel, buyCost := g.clickPurchase(i)
+		costText := fmt.Sprintf("Cost: %s (+%.2f speed)", g.format(buyCost), generator.speedPerLevel)
+		if buyLabel != buyQuantityLabel(1) {
+			costText = fmt.Sprintf("Buy %s: %s (+%.2f speed)", buyLabel, g.format(buyCost), generator.speedPerLevel)
+		}
+		switch {
+		case generator.retired:
+			nameText = fmt.Sprintf("%s: Retired", generator.name)
+			costText = "Earned a retirement token"
+		case generator.level >= maxGeneratorLevel:
+			costText = "Maxed - right-click to retire"
+		}
+		speedText := fmt.Sprintf("Speed: %s", g.format(currentSpeed))
+		if g.settings.ShowNextGain {
+			if seconds, ok := g.nextGainIn(i); ok {
+				speedText += fmt.Sprintf(" (next +%.3g in %.1fs)", generator.multiplierGain, seconds)
+			} else {
+				speedText += " (not rotating)"
+			}
+		}
 		multiplierText := fmt.Sprintf("Multiplier: x%.2f", generator.manaMultiplier)
+		if generator.active() {
+			multiplierText += fmt.Sprintf(" (+%s/sec per rotation)", g.format(g.manaPerRotation(i)))
+		}
+		
+		// Maxed generators can't be bought anymore, gray out the whole panel
+		panelColor := func(c color.RGBA) color.RGBA {
+			if generator.level >= maxGeneratorLevel {
+				return color.RGBA{120, 120, 120, 255}
+			}
+			return c
+		}
 		
 		// Name
 		op1 := &text.DrawOptions{}
 		op1.GeoM.Translate(float64(textX), float64(textY))
-		op1.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
-		text.Draw(screen, nameText, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   28,
-		}, op1)
+		op1.ColorScale.ScaleWithColor(panelColor(theme.text))
+		text.Draw(screen, nameText, g.face(28), op1)
+		
+		// Levels the current mana pays for, in the top right corner
+		if !generator.retired && generator.level < maxGeneratorLevel {
+			opAfford := &text.DrawOptions{}
+			opAfford.GeoM.Translate(float64(textX+panelW-8), float64(textY+8))
+			opAfford.PrimaryAlign = text.AlignEnd
+			opAfford.ColorScale.ScaleWithColor(theme.textDim)
+			text.Draw(screen, fmt.Sprintf("Can buy: %d", g.cachedMaxAffordable(i)), g.face(16), opAfford)
+		}
 		
-		// Cost
+		// Cost, colored by whether the next level is affordable
+		costColor := theme.unaffordable
+		if g.canAfford(buyCost) {
+			costColor = theme.affordable
+		}
 		op2 := &text.DrawOptions{}
 		op2.GeoM.Translate(float64(textX), float64(textY+40))
-		op2.ColorScale.ScaleWithColor(color.RGBA{200, 200, 200, 255})
-		text.Draw(screen, costText, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   20,
-		}, op2)
+		op2.ColorScale.ScaleWithColor(panelColor(costColor))
+		text.Draw(screen, costText, g.face(20), op2)
 		
 		// Speed
 		op3 := &text.DrawOptions{}
 		op3.GeoM.Translate(float64(textX), float64(textY+70))
-		op3.ColorScale.ScaleWithColor(color.RGBA{200, 200, 200, 255})
-		text.Draw(screen, speedText, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   20,
-		}, op3)
+		op3.ColorScale.ScaleWithColor(panelColor(theme.textDim))
+		text.Draw(screen, speedText, g.face(20), op3)
 		
 		// Multiplier
 		op4 := &text.DrawOptions{}
 		op4.GeoM.Translate(float64(textX), float64(textY+100))
-		op4.ColorScale.ScaleWithColor(color.RGBA{100, 255, 100, 255})
-		text.Draw(screen, multiplierText, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   20,
-		}, op4)
+		op4.ColorScale.ScaleWithColor(panelColor(theme.multiplier))
+		text.Draw(screen, multiplierText, g.face(20), op4)
+		
+		// Multiplier growth over the last minute
+		if g.settings.Sparklines {
+			g.drawSparkline(screen, &g.multiplierHistories[i], float32(textX), float32(textY+sparklineOffsetY), panelColor(theme.multiplier))
+		}
+		
+		// Marginal gain of the next level while hovering the panel
+		if g.settings.PurchasePreview && !generator.retired && generator.level < maxGeneratorLevel && i == hovered {
+			rateDelta, growthDelta := g.previewPurchase(i)
+			op5 := &text.DrawOptions{}
+			op5.GeoM.Translate(float64(textX), float64(textY+165))
+			op5.ColorScale.ScaleWithColor(costColor)
+			text.Draw(screen, fmt.Sprintf("Buying: %+.2f/sec now, %+.4f/sec per second", rateDelta, growthDelta), g.face(18), op5)
+		}
 	}
 	
 	// Draw production status in center
 	g.drawCenterProductionStatus(screen, centerX, centerY)
 }
 
+// Radius of generator i's orbit out of n. Orbits start 100px out and are 50px
+// apart, packed closer when there are too many to fit within maxOrbitRadius.
+func orbitRadius(i, n int) float32 {
+	spacing := float32(50)
+	if n > 1 {
+		spacing = min(spacing, (maxOrbitRadius-100)/float32(n-1))
+	}
+	return 100 + float32(i)*spacing
+}
+
 func (g *Game) drawCenterProductionStatus(screen *ebiten.Image, centerX, centerY float32) {
+	// Apply the player's pan and zoom
+	centerX, centerY, zoom := g.orbitView(centerX, centerY)
+	
 	// Draw rotating indicators for each generator (scaled for larger screen)
 	for i, generator := range g.generators {
-		if generator.level > 0 {
-			indicatorRadius := float32(100 + i*50) // Scaled from 40+i*20 to 100+i*50
+		if generator.owned() {
+			indicatorRadius := orbitRadius(i, len(g.generators)) * zoom
 			
 			// Calculate indicator position based on rotation
 			angle := float32(g.rotationAngles[i])
 			indicatorX := centerX + indicatorRadius*float32(math.Cos(float64(angle)))
 			indicatorY := centerY + indicatorRadius*float32(math.Sin(float64(angle)))
 			
-			// Draw rotating indicator (larger circle)
-			colors := []color.RGBA{
-				{255, 100, 100, 255}, // Red
-				{255, 200, 100, 255}, // Orange  
-				{100, 255, 100, 255}, // Green
-				{100, 200, 255, 255}, // Blue
+			// Draw rotating indicator (larger circle) in the theme's color
+			indicatorColor := g.theme().indicator(i)
+			if !generator.enabled {
+				indicatorColor = dimmed(indicatorColor)
+			}
+			
+			// Newly unlocked generators draw their orbit progressively before the indicator appears
+			if remaining := g.unlockAnimations[i]; remaining > 0 {
+				progress := float32(1 - remaining/unlockAnimationDuration)
+				pathColor := indicatorColor
+				pathColor.A = 80
+				g.drawArcSegment(screen, centerX, centerY, indicatorRadius, 6*zoom, -math.Pi/2, -math.Pi/2+progress*2*math.Pi, pathColor)
+				continue
 			}
 			
+			// Progress ring: the orbit fills behind the indicator until the next multiplier gain
+			progressColor := indicatorColor
+			progressColor.A = 180
+			g.drawArcSegment(screen, centerX, centerY, indicatorRadius, 4*zoom, 0, angle, progressColor)
+			
 			// Draw larger indicator with glow effect (scaled)
-			glowColor := colors[i]
-			glowColor.A = 100
-			vector.DrawFilledCircle(screen, indicatorX, indicatorY, 20, glowColor, false) // Glow (scaled from 8 to 20)
-			vector.DrawFilledCircle(screen, indicatorX, indicatorY, 12, colors[i], false) // Main dot (scaled from 5 to 12)
+			glowColor := indicatorColor
+			glowColor.A = uint8(max(0, min(255, g.settings.GlowIntensity)))
+			vector.DrawFilledCircle(screen, indicatorX, indicatorY, 20*zoom, glowColor, g.antialias()) // Glow (scaled from 8 to 20)
+			vector.DrawFilledCircle(screen, indicatorX, indicatorY, 12*zoom, indicatorColor, g.antialias()) // Main dot (scaled from 5 to 12)
 			
 			// Draw orbit path (faint circle with thicker stroke)
-			pathColor := colors[i]
-			pathColor.A = 80
-			vector.StrokeCircle(screen, centerX, centerY, indicatorRadius, 3, pathColor, false) // Thicker stroke (1 to 3)
+			if g.settings.OrbitPaths {
+				pathColor := indicatorColor
+				pathColor.A = 80
+				vector.StrokeCircle(screen, centerX, centerY, indicatorRadius, 3*zoom, pathColor, g.antialias()) // Thicker stroke (1 to 3)
+			}
 		}
 	}
 }
 
-func (g *Game) drawArcSegment(screen *ebiten.Image, centerX, centerY, radius, thickness, startAngle, endAngle float32, col color.RGBA) {
-	segments := 32
-	angleStep := (endAngle - startAngle) / float32(segments)
-	
-	for i := 0; i < segments; i++ {
-		angle1 := startAngle + float32(i)*angleStep
-		angle2 := startAngle + float32(i+1)*angleStep
-		
-		// Draw thick stroke segments using StrokeCircle for simplicity
-		midAngle := (angle1 + angle2) / 2
-		x := centerX + radius*float32(math.Cos(float64(midAngle)))
-		y := centerY + radius*float32(math.Sin(float64(midAngle)))
-		
-		vector.DrawFilledCircle(screen, x, y, thickness/2, col, false)
+// Corner panel of generator i, shared by drawing and click handling
+func (g *Game) generatorRect(i int) (x, y, w, h int) {
+	if g.shopListShown() {
+		return g.shopRowRect(i)
+	}
+	w, h = 370, 130
+	switch i {
+	case 0: // Top left
+		return 30, 120, w, h
+	case 1: // Top right
+		return g.width - 400, 120, w, h
+	case 2: // Bottom left
+		return 30, g.height - 200, w, h
 	}
+	// Bottom right
+	return g.width - 400, g.height - 200, w, h
 }
 
-func (g *Game) handleGeneratorClicks(x, y int) {
+// Index of the generator panel at x, y or -1 if there is none
+func (g *Game) generatorAt(x, y int) int {
+	if g.shopListShown() {
+		return g.shopRowAt(x, y)
+	}
+	
 	// Check corner text area clicks only (scaled click areas)
-	for i := range g.generators {
-		var textX, textY int
-		switch i {
-		case 0: // Top left
-			textX = 30
-			textY = 120
-		case 1: // Top right
-			textX = screenWidth - 400
-			textY = 120
-		case 2: // Bottom left
-			textX = 30
-			textY = screenHeight - 200
-		case 3: // Bottom right
-			textX = screenWidth - 400
-			textY = screenHeight - 200
+	for i := range g.shownGenerators() {
+		textX, textY, w, h := g.generatorRect(i)
+		if x >= textX && x <= textX+w &&
+			y >= textY && y <= textY+h {
+			return i
 		}
+	}
+	return -1
+}
+
+func (g *Game) handleGeneratorClicks(x, y int) {
+	if i := g.generatorAt(x, y); i >= 0 && i < len(g.generators) {
+		g.totalClicks++
 		
-		if x >= textX && x <= textX+370 &&
-			y >= textY && y <= textY+130 {
-			
-			if g.mana >= float64(g.generators[i].cost) {
-				// Check if generator can be leveled up (max level 100)
-				if g.generators[i].level < 100 {
-					g.mana -= float64(g.generators[i].cost)
-					g.generators[i].level++
-					
-					// Speed is automatically calculated as level * speedPerLevel
-					// No need to manually add speed increment
-					
-					// Recalculate mana per second with new multiplicative values
-					g.calculateManaPerSec()
-					
-					// Increase cost for next purchase (different scaling per generator)
-					scalingFactor := []float64{1.15, 1.2, 1.2, 1.2}[i] // Mana Crystal has slower scaling
-					g.generators[i].cost = g.generators[i].cost * scalingFactor
-				}
-			}
-			break
+		// A double-click buys as many levels as affordable, on top of the first click's levels
+		window := float64(g.settings.DoubleClickWindow) / 1000
+		if isDoubleClick(g.lastPanelClick, g.lastPanelClickAt, i, g.animationTime, window) {
+			g.playPurchaseSound(g.buyBulk(i, buyMax))
+			g.lastPanelClick = -1
+			return
 		}
+		g.playPurchaseSound(g.buyBulk(i, g.clickBuyQuantity(i)))
+		g.lastPanelClick, g.lastPanelClickAt = i, g.animationTime
+	}
+}
+
+// Whether a click on panel i at now completes a double-click started by a click on
+// panel last at lastAt; a zero window disables double-clicks
+func isDoubleClick(last int, lastAt float64, i int, now, window float64) bool {
+	return window > 0 && last == i && now-lastAt <= window
+}
+
+// Buy one level of generator i, reporting whether the purchase happened
+func (g *Game) buyGenerator(i int) bool {
+	return g.buyBulk(i, 1) == 1
+}
+
+// Buy up to n levels of generator i as part of a purchase action that already bought
+// done levels, stopping at the per-action cap. Returns how many levels were bought.
+func (g *Game) buyLevels(i, n, done int) int {
+	if limit := g.settings.MaxBuyPerAction; limit > 0 {
+		n = min(n, limit-done)
+	}
+	
+	bought := 0
+	for bought < n && g.buyGenerator(i) {
+		bought++
 	}
+	return bought
 }
 
 func main() {
-	ebiten.SetWindowSize(screenWidth, screenHeight)
-	ebiten.SetWindowTitle("Magic Click - Mana Generator")
+	variant := flag.String("variant", "", "economy variant: independent or shared (default: keep the saved one)")
+	difficultyName := flag.String("difficulty", "", "difficulty preset: easy, normal or hard (default: keep the saved one)")
+	debug := flag.Bool("debug", false, "enable debug actions (F9: stress test, F7: fast forward)")
+	gifRecording := flag.Bool("gif", false, "enable recording the screen to an animated GIF (F10: start/stop)")
+	daily := flag.Bool("daily", false, "play today's daily challenge, seeded from the date and saved separately")
+	statusAddr := flag.String("status", "", "serve the game state as JSON on this address, e.g. localhost:8080")
+	width := flag.Int("width", 0, "window width in pixels (default: the saved size)")
+	height := flag.Int("height", 0, "window height in pixels (default: the saved size)")
+	startingMana := flag.Float64("mana", 0, "debug: start with this much mana")
+	flag.Parse()
+	
+	ebiten.SetWindowTitle(baseWindowTitle)
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	
-	game := NewGame()
+	var game *Game
+	if *daily {
+		game = newDailyChallenge(time.Now())
+	} else {
+		game = NewGame()
+	}
+	if *variant != "" {
+		mode, err := parseTimerMode(*variant)
+		if err != nil {
+			log.Fatal(err)
+		}
+		game.timerMode = mode
+	}
+	if *difficultyName != "" {
+		difficulty, err := parseDifficulty(*difficultyName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		game.difficulty = difficulty
+	}
+	game.debug = *debug
+	game.gifEnabled = *gifRecording
+	if *statusAddr != "" {
+		status, err := startStatusServer(*statusAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		game.status = status
+	}
+	if *startingMana != 0 {
+		if err := game.setDebugMana(*startingMana); err != nil {
+			log.Printf("ignoring -mana: %v", err)
+		}
+	}
+	if *width != 0 || *height != 0 {
+		if err := game.settings.setWindowSize(*width, *height); err != nil {
+			log.Printf("ignoring -width/-height: %v", err)
+		}
+	}
+	game.applySettings()
+	ebiten.SetWindowSize(game.settings.windowSize())
 	
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
+	
+	// Save progress and the window size once the window is closed
+	if err := game.SaveGame(game.savePath); err != nil {
+		log.Printf("failed to save: %v", err)
+	}
+	game.saveSettings()
 }
\ No newline at end of file
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"math/rand/v2"
+	"path/filepath"
+	"testing"
+)
+
+// Create a silent game with a fixed seed whose save and settings live in a
+// temporary directory
+func newTestGame(t testing.TB, opts ...GameOption) *Game {
+	t.Helper()
+	dir := t.TempDir()
+	opts = append([]GameOption{WithoutAudio(), WithRand(rand.New(rand.NewPCG(1, 2)))}, opts...)
+	return newGameWithPaths(0, filepath.Join(dir, "save.json"), filepath.Join(dir, "settings.json"), opts...)
+}
+
+// scriptedSource is a rand.Source returning a predetermined sequence, starting
+// over once it runs out
+type scriptedSource struct {
+	values []uint64
+	next   int
+}
+
+func (s *scriptedSource) Uint64() uint64 {
+	v := s.values[s.next%len(s.values)]
+	s.next++
+	return v
+}
+
+// Random source whose Float64 calls return floats in order. Each must be a
+// multiple of 2^-53 in [0, 1) to come back exactly.
+func scriptedRand(floats ...float64) *rand.Rand {
+	s := &scriptedSource{}
+	for _, f := range floats {
+		s.values = append(s.values, uint64(f*(1<<53)))
+	}
+	return rand.New(s)
+}
+
+func TestScriptedRand(t *testing.T) {
+	r := scriptedRand(0.25, 0.5, 0.75)
+	for _, want := range []float64{0.25, 0.5, 0.75, 0.25} {
+		if got := r.Float64(); got != want {
+			t.Errorf("Float64() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestWithRandDrivesRolls(t *testing.T) {
+	// The script decides every roll: a lucky click, then a normal one
+	g := newTestGame(t, WithRand(scriptedRand(0, 0.99)))
+	if g.seed != 0 {
+		t.Errorf("seed = %d with an injected source, want 0", g.seed)
+	}
+	if !g.rollCrit() {
+		t.Error("roll of 0 wasn't lucky")
+	}
+	if g.rollCrit() {
+		t.Error("roll of 0.99 was lucky")
+	}
+}
+
+func TestNewGameWithoutAudio(t *testing.T) {
+	g := newTestGame(t)
+	if g.sounds != nil {
+		t.Fatal("sounds were set up although audio is disabled")
+	}
+	// Playing sounds without audio must be a no-op
+	g.playClickSound()
+	g.playBuySound()
+}
@@ -0,0 +1,47 @@
+package main
+
+import "math"
+
+// Replace the balance with v
+func (g *Game) setMana(v float64) {
+	g.mana = NewBigNumber(g.finite("mana", v, 0))
+}
+
+// Mana as a float64, +Inf once the balance is past its range
+func (g *Game) manaValue() float64 {
+	return g.mana.Float64()
+}
+
+// Whether the balance covers cost
+func (g *Game) canAfford(cost float64) bool {
+	return g.mana.Cmp(NewBigNumber(cost)) >= 0
+}
+
+// Take cost out of the balance, which the caller checked with canAfford
+func (g *Game) spend(cost float64) {
+	if !isFinite(cost) {
+		g.warnNonFinite("mana", math.Inf(-1))
+		return
+	}
+	g.mana = g.mana.Add(NewBigNumber(-cost))
+	// Rounding must not leave a sliver of debt
+	if g.mana.sign() < 0 {
+		g.mana = BigNumber{}
+	}
+}
+
+// Format a number that may be past float64's range
+func (g *Game) formatBig(b BigNumber) string {
+	if v := b.Float64(); isFinite(v) {
+		return g.format(v)
+	}
+	return b.String()
+}
+
+// Format the balance for the readout, in scientific notation past float64's range
+func (g *Game) formatMana() string {
+	if v := g.manaValue(); isFinite(v) {
+		return g.formatManaReadout(v)
+	}
+	return g.mana.String()
+}
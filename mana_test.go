@@ -0,0 +1,142 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManaPastFloat64(t *testing.T) {
+	g := newTestGame(t)
+	g.mana = BigNumber{mantissa: 1, exp: 400}
+	g.storageLevel = storageLevelFor(g.mana) + 1
+
+	if v := g.manaValue(); isFinite(v) {
+		t.Fatalf("manaValue() = %v, want +Inf", v)
+	}
+	// The cap, the storage cost and production all keep going
+	g.earnMana(BigNumber{mantissa: 2, exp: 400})
+	if want := (BigNumber{mantissa: 3, exp: 400}); g.mana.Cmp(want) != 0 {
+		t.Errorf("mana = %v, want %v", g.mana, want)
+	}
+	if !g.canAfford(1e300) {
+		t.Error("a finite cost isn't affordable with a balance past float64's range")
+	}
+	g.earnMana(BigNumber{mantissa: 3, exp: 400})
+	if !g.buyStorage() {
+		t.Fatal("storage upgrade past float64's range failed")
+	}
+	if got, want := g.formatMana(), "1.00e400"; got != want {
+		t.Errorf("formatMana() = %q, want %q", got, want)
+	}
+}
+
+func TestManaCapPastFloat64(t *testing.T) {
+	g := newTestGame(t)
+	g.storageLevel = 400
+	if want := (BigNumber{mantissa: 1, exp: 404}); g.manaCap().Cmp(want) != 0 {
+		t.Errorf("manaCap() = %v, want %v", g.manaCap(), want)
+	}
+	g.mana = g.manaCap()
+	g.earnMana(NewBigNumber(1))
+	if !g.atManaCap() || g.mana.Cmp(g.manaCap()) != 0 {
+		t.Errorf("mana = %v grew past the cap %v", g.mana, g.manaCap())
+	}
+}
+
+func TestProductionPastFloat64(t *testing.T) {
+	g := newTestGame(t)
+	g.config.SoftCap = 0
+	for i := range g.generators {
+		g.generators[i].manaMultiplier = 1e300
+	}
+	g.calculateManaPerSec()
+	if g.manaPerSec.Cmp(NewBigNumber(1e300)) <= 0 || isFinite(g.manaPerSec.Float64()) {
+		t.Errorf("manaPerSec = %v, want past float64's range", g.manaPerSec)
+	}
+	if !isFinite(g.totalMultiplier) {
+		t.Errorf("totalMultiplier = %v, want it clamped", g.totalMultiplier)
+	}
+
+	// The soft cap brings even such a rate back to a float64
+	g.config.SoftCap = 1000
+	g.calculateManaPerSec()
+	if v := g.manaPerSec.Float64(); !isFinite(v) || v <= 1000 {
+		t.Errorf("soft capped manaPerSec = %v", g.manaPerSec)
+	}
+}
+
+func TestStorageLevelFor(t *testing.T) {
+	tests := []struct {
+		mana BigNumber
+		want int
+	}{
+		{BigNumber{}, 0},
+		{NewBigNumber(-5), 0},
+		{NewBigNumber(baseManaCap), 0},
+		{NewBigNumber(baseManaCap + 1), 1},
+		{NewBigNumber(1e5), 1},
+		{NewBigNumber(1e5 + 1), 2},
+		{BigNumber{mantissa: 1.5, exp: 400}, 397},
+		{BigNumber{mantissa: 1, exp: 1e15}, 1e15 - 4},
+	}
+	for _, tt := range tests {
+		if got := storageLevelFor(tt.mana); got != tt.want {
+			t.Errorf("storageLevelFor(%v) = %d, want %d", tt.mana, got, tt.want)
+		}
+	}
+}
+
+func TestBigManaSaveRoundTrip(t *testing.T) {
+	g := newTestGame(t)
+	g.mana = BigNumber{mantissa: 1.5, exp: 400}
+	g.storageLevel = storageLevelFor(g.mana)
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := g.SaveGame(path); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"mana": "1.5e400"`) {
+		t.Errorf("mana isn't saved as a decimal string: %s", b)
+	}
+
+	loaded := newTestGame(t)
+	if err := loaded.LoadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.mana.Cmp(g.mana) != 0 || loaded.storageLevel != g.storageLevel {
+		t.Errorf("loaded mana %v at storage %d, want %v at %d", loaded.mana, loaded.storageLevel, g.mana, g.storageLevel)
+	}
+}
+
+func TestParseBigNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    BigNumber
+		wantErr bool
+	}{
+		{in: "0", want: BigNumber{}},
+		{in: "1234.5", want: NewBigNumber(1234.5)},
+		{in: "1.5e400", want: BigNumber{mantissa: 1.5, exp: 400}},
+		{in: "15e399", want: BigNumber{mantissa: 1.5, exp: 400}},
+		{in: "2.5e+10", want: NewBigNumber(2.5e10)},
+		{in: "1e-5", want: NewBigNumber(1e-5)},
+		{in: "abc", wantErr: true},
+		{in: "1.5eX", wantErr: true},
+		{in: "NaN", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseBigNumber(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseBigNumber(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.Cmp(tt.want) != 0 {
+			t.Errorf("parseBigNumber(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
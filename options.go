@@ -0,0 +1,366 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Options menu layout
+const (
+	optionsColumnWidth = 640
+	optionsRowHeight   = 36
+	optionsPadding     = 30
+)
+
+// Rows that need a confirming second click
+const (
+	deleteSlotLabel = "Delete slot progress"
+	resetGameLabel  = "Reset game"
+)
+
+// optionRow is one line of the options menu; clicking it cycles to the next value
+type optionRow struct {
+	label string
+	value func(g *Game) string
+	next  func(g *Game)
+}
+
+var optionRows = []optionRow{
+	{
+		label: "FPS cap",
+		value: (*Game).fpsCapStatus,
+		next: func(g *Game) {
+			g.settings.FPSCap = nextInCycle(fpsCaps, g.settings.FPSCap)
+			g.applySettings()
+		},
+	},
+	{
+		label: "Theme",
+		value: func(g *Game) string { return g.theme().name },
+		next: func(g *Game) {
+			g.settings.Theme = nextInCycle(g.themeNames(), g.theme().name)
+		},
+	},
+	{
+		label: "Window title",
+		value: func(g *Game) string { return g.settings.TitleMode },
+		next: func(g *Game) {
+			g.settings.TitleMode = nextInCycle(titleModes, g.settings.TitleMode)
+			g.titleTimer = 0
+			g.updateWindowTitle(0)
+		},
+	},
+	{
+		label: "Title update interval",
+		value: func(g *Game) string { return fmt.Sprintf("%ds", g.settings.TitleInterval) },
+		next: func(g *Game) {
+			g.settings.TitleInterval = nextInCycle(titleIntervals, g.settings.TitleInterval)
+		},
+	},
+	{
+		label: "Sparklines",
+		value: func(g *Game) string { return onOff(g.settings.Sparklines) },
+		next:  func(g *Game) { g.settings.Sparklines = !g.settings.Sparklines },
+	},
+	{
+		label: "Orbit glow",
+		value: func(g *Game) string { return fmt.Sprintf("%d", g.settings.GlowIntensity) },
+		next: func(g *Game) {
+			g.settings.GlowIntensity = nextInCycle(glowIntensities, g.settings.GlowIntensity)
+		},
+	},
+	{
+		label: "Production timing",
+		value: func(g *Game) string { return g.settings.Accrual.String() },
+		next: func(g *Game) {
+			g.settings.Accrual = nextInCycle([]accrualMode{accrualRealTime, accrualFixedTick}, g.settings.Accrual)
+		},
+	},
+	{
+		label: "Mana display",
+		value: func(g *Game) string { return g.settings.ManaRounding },
+		next: func(g *Game) {
+			g.settings.ManaRounding = nextInCycle(manaRoundings, g.settings.ManaRounding)
+		},
+	},
+	{
+		label: "Number format",
+		value: func(g *Game) string { return g.settings.NumberFormat },
+		next: func(g *Game) {
+			g.settings.NumberFormat = nextInCycle(numberFormats, g.settings.NumberFormat)
+		},
+	},
+	{
+		label: "Buy key repeat",
+		value: func(g *Game) string { return fmt.Sprintf("%d/sec", g.settings.BuyRepeatRate) },
+		next: func(g *Game) {
+			g.settings.BuyRepeatRate = nextInCycle(buyRepeatRates, g.settings.BuyRepeatRate)
+		},
+	},
+	{
+		label: "Reduce motion",
+		value: func(g *Game) string { return onOff(g.settings.ReduceMotion) },
+		next:  func(g *Game) { g.settings.ReduceMotion = !g.settings.ReduceMotion },
+	},
+	{
+		label: "Circle quality",
+		value: func(g *Game) string { return g.settings.CircleQuality },
+		next: func(g *Game) {
+			g.settings.CircleQuality = nextInCycle(circleQualities, g.settings.CircleQuality)
+		},
+	},
+	{
+		label: "Orbit paths",
+		value: func(g *Game) string { return onOff(g.settings.OrbitPaths) },
+		next:  func(g *Game) { g.settings.OrbitPaths = !g.settings.OrbitPaths },
+	},
+	{
+		label: "Next gain timer",
+		value: func(g *Game) string { return onOff(g.settings.ShowNextGain) },
+		next:  func(g *Game) { g.settings.ShowNextGain = !g.settings.ShowNextGain },
+	},
+	{
+		label: "Purchase preview on hover",
+		value: func(g *Game) string { return onOff(g.settings.PurchasePreview) },
+		next:  func(g *Game) { g.settings.PurchasePreview = !g.settings.PurchasePreview },
+	},
+	{
+		label: "Max levels per purchase",
+		value: func(g *Game) string {
+			if g.settings.MaxBuyPerAction == 0 {
+				return "Unlimited"
+			}
+			return fmt.Sprintf("%d", g.settings.MaxBuyPerAction)
+		},
+		next: func(g *Game) {
+			g.settings.MaxBuyPerAction = nextInCycle(maxBuyPerActions, g.settings.MaxBuyPerAction)
+		},
+	},
+	{
+		label: "Double-click to buy max",
+		value: func(g *Game) string {
+			if g.settings.DoubleClickWindow == 0 {
+				return "Off"
+			}
+			return fmt.Sprintf("within %dms", g.settings.DoubleClickWindow)
+		},
+		next: func(g *Game) {
+			g.settings.DoubleClickWindow = nextInCycle(doubleClickWindows, g.settings.DoubleClickWindow)
+		},
+	},
+	{
+		label: "Baseline trickle",
+		value: func(g *Game) string { return onOff(g.settings.BaselineTrickle) },
+		next: func(g *Game) {
+			g.settings.BaselineTrickle = !g.settings.BaselineTrickle
+			g.calculateManaPerSec()
+		},
+	},
+	{
+		label: "Min orb click interval",
+		value: func(g *Game) string {
+			if g.settings.MinClickInterval == 0 {
+				return "Off"
+			}
+			return fmt.Sprintf("%dms", g.settings.MinClickInterval)
+		},
+		next: func(g *Game) {
+			g.settings.MinClickInterval = nextInCycle(minClickIntervals, g.settings.MinClickInterval)
+		},
+	},
+	{
+		label: "Background tab catch-up",
+		value: func(g *Game) string { return onOff(g.settings.TabCatchUp) },
+		next:  func(g *Game) { g.settings.TabCatchUp = !g.settings.TabCatchUp },
+	},
+	{
+		label: "Compress saves",
+		value: func(g *Game) string { return onOff(g.settings.CompressSaves) },
+		next:  func(g *Game) { g.settings.CompressSaves = !g.settings.CompressSaves },
+	},
+	{
+		label: "Sound",
+		value: func(g *Game) string {
+			if g.settings.Muted {
+				return "Muted (M)"
+			}
+			return fmt.Sprintf("%d%%", g.settings.Volume)
+		},
+		next: func(g *Game) {
+			// Cycling past the loudest volume mutes, the next click unmutes at the quietest
+			switch {
+			case g.settings.Muted:
+				g.settings.Muted = false
+				g.settings.Volume = volumes[0]
+			case g.settings.Volume == volumes[len(volumes)-1]:
+				g.settings.Muted = true
+			default:
+				g.settings.Volume = nextInCycle(volumes, g.settings.Volume)
+			}
+		},
+	},
+	{
+		label: "Generator layout",
+		value: func(g *Game) string {
+			if n := g.shownGenerators(); n > cornerPanels {
+				return fmt.Sprintf("List (%d generators need it)", n)
+			}
+			return g.settings.GeneratorLayout
+		},
+		next: func(g *Game) {
+			g.settings.GeneratorLayout = nextInCycle(generatorLayouts, g.settings.GeneratorLayout)
+		},
+	},
+	{
+		label: "Fullscreen (F11)",
+		value: func(g *Game) string { return onOff(g.settings.Fullscreen) },
+		next: func(g *Game) {
+			g.settings.Fullscreen = !g.settings.Fullscreen
+			ebiten.SetFullscreen(g.settings.Fullscreen)
+		},
+	},
+	{
+		label: "Pause when unfocused",
+		value: func(g *Game) string { return onOff(g.settings.PauseOnBlur) },
+		next: func(g *Game) {
+			g.settings.PauseOnBlur = !g.settings.PauseOnBlur
+			ebiten.SetRunnableOnUnfocused(!g.settings.PauseOnBlur)
+		},
+	},
+	{
+		label: "Economy variant",
+		value: func(g *Game) string { return g.timerMode.String() + " timers" },
+		next: func(g *Game) {
+			g.timerMode = nextInCycle(timerModes, g.timerMode)
+			g.logEvent("switched to %s timers", g.timerMode)
+		},
+	},
+	{
+		label: "Save slot",
+		value: func(g *Game) string { return fmt.Sprintf("%d of %d", g.slot, saveSlots) },
+		next:  func(g *Game) { g.switchSlot(nextInCycle(slotNumbers(), g.slot)) },
+	},
+	{
+		label: deleteSlotLabel,
+		value: func(g *Game) string {
+			if g.confirmingSlotDelete {
+				return fmt.Sprintf("click again to delete slot %d", g.slot)
+			}
+			return fmt.Sprintf("slot %d", g.slot)
+		},
+		next: func(g *Game) { g.confirmDeleteSlot() },
+	},
+	{
+		label: resetGameLabel,
+		value: func(g *Game) string {
+			if g.confirmingReset {
+				return fmt.Sprintf("click again to confirm (%.0fs)", math.Ceil(g.resetConfirmTimer))
+			}
+			return "clears all progress, keeps settings"
+		},
+		next: func(g *Game) { g.confirmReset() },
+	},
+	{
+		label: "Promo code",
+		value: func(g *Game) string { return g.promoStatus() },
+		next:  func(g *Game) { g.beginPromoInput() },
+	},
+}
+
+// Return the value following current in values, wrapping around
+func nextInCycle[T comparable](values []T, current T) T {
+	for i, v := range values {
+		if v == current {
+			return values[(i+1)%len(values)]
+		}
+	}
+	return values[0]
+}
+
+func onOff(b bool) string {
+	if b {
+		return "On"
+	}
+	return "Off"
+}
+
+// Rows are split into two columns when the window is wide enough for them
+func (g *Game) optionsColumns() int {
+	if g.width >= optionsPadding*2+optionsColumnWidth*2 {
+		return 2
+	}
+	return 1
+}
+
+// Size of the options panel
+func (g *Game) optionsSize() (w, h int) {
+	columns := g.optionsColumns()
+	perColumn := (len(optionRows) + columns - 1) / columns
+	return optionsPadding*2 + optionsColumnWidth*columns, optionsPadding*2 + optionsRowHeight*(perColumn+1)
+}
+
+// Top-left corner of the options panel
+func (g *Game) optionsOrigin() (int, int) {
+	w, h := g.optionsSize()
+	return max(0, (g.width-w)/2), max(0, (g.height-h)/2)
+}
+
+// Top-left corner of option row i, filling the first column before the second
+func (g *Game) optionRowOrigin(i int) (int, int) {
+	originX, originY := g.optionsOrigin()
+	columns := g.optionsColumns()
+	perColumn := (len(optionRows) + columns - 1) / columns
+	return originX + optionsPadding + optionsColumnWidth*(i/perColumn), originY + optionsPadding + optionsRowHeight*(i%perColumn+1)
+}
+
+// Handle a click while the options menu is open
+func (g *Game) handleOptionsClick(x, y int) {
+	for i, row := range optionRows {
+		rowX, rowY := g.optionRowOrigin(i)
+		if x >= rowX && x < rowX+optionsColumnWidth &&
+			y >= rowY && y < rowY+optionsRowHeight {
+			// Any other click cancels a pending slot deletion or reset
+			if row.label != deleteSlotLabel {
+				g.confirmingSlotDelete = false
+			}
+			if row.label != resetGameLabel {
+				g.confirmingReset = false
+			}
+			row.next(g)
+			g.saveSettings()
+			return
+		}
+	}
+	g.confirmingSlotDelete = false
+	g.confirmingReset = false
+}
+
+func (g *Game) drawOptions(screen *ebiten.Image) {
+	// Dim the game behind the menu
+	vector.DrawFilledRect(screen, 0, 0, float32(g.width), float32(g.height), color.RGBA{0, 0, 0, 160}, false)
+
+	originX, originY := g.optionsOrigin()
+	width, height := g.optionsSize()
+	vector.DrawFilledRect(screen, float32(originX), float32(originY), float32(width), float32(height), color.RGBA{40, 40, 80, 240}, false)
+	vector.StrokeRect(screen, float32(originX), float32(originY), float32(width), float32(height), 2, color.RGBA{150, 150, 220, 255}, false)
+
+	// Title
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(originX+optionsPadding), float64(originY+optionsPadding))
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, "Options (O to close, click to change)", g.face(24), op)
+
+	for i, row := range optionRows {
+		rowX, rowY := g.optionRowOrigin(i)
+
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(rowX), float64(rowY+8))
+		op.ColorScale.ScaleWithColor(color.RGBA{200, 200, 200, 255})
+		text.Draw(screen, fmt.Sprintf("%s: %s", row.label, row.value(g)), g.face(20), op)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const baseOrbClickValue = 1.0 // Mana a single orb click is worth at the start
+
+// Orb look
+const (
+	orbPulseAmount = 0.05 // Share of the radius the idle pulse adds and removes
+	orbPulseSpeed  = 2.0  // Radians of the pulse per second
+	orbClickScale  = 0.15 // Extra size right after a click, shrinking with clickAnimation
+	orbGlowScale   = 1.4  // Glow radius relative to the orb
+)
+
+// Floating "+N" text spawned by orb clicks
+const (
+	maxFloatTexts     = 32  // Oldest texts are dropped beyond this during rapid clicking
+	floatTextLifetime = 1.0 // Seconds until a text has faded out
+	floatTextRise     = 60  // Pixels per second a text drifts upward
+)
+
+// floatText is a "+N" label floating up from a counted orb click
+type floatText struct {
+	x, y  float64
+	life  float64 // Seconds left before it disappears
+	value float64
+	crit  bool // Lucky click, drawn larger in gold
+}
+
+// Selectable minimum times between counted orb clicks in milliseconds, 0 means off
+var minClickIntervals = []int{0, 50, 100, 250}
+
+// Count a click on the orb unless it came sooner than the minimum interval
+// after the previous counted one, reporting whether it was counted. Counted
+// clicks add orbClickValue to mana, or a multiple of it when they're lucky.
+func (g *Game) clickOrb() bool {
+	if g.economyFrozen() {
+		return false
+	}
+
+	interval := float64(g.settings.MinClickInterval) / 1000
+	if g.orbClicks > 0 && !clickAllowed(g.lastOrbClick, g.animationTime, interval) {
+		return false
+	}
+
+	g.orbClicks++
+	g.lastOrbClick = g.animationTime
+	g.totalClicks++
+	g.playClickSound()
+	value, crit := g.orbClickValue, g.rollCrit()
+	if crit {
+		value *= g.critMultiplier()
+	}
+	g.addMana(value)
+	g.spawnClickText(value, crit)
+	g.orbClicked = true
+	g.clickAnimation = 10
+	return true
+}
+
+// Whether a click at now (seconds) is far enough from the previous counted click at last
+func clickAllowed(last, now, interval float64) bool {
+	return now-last >= interval
+}
+
+// Spawn a float text at the cursor, or at the orb's center when the click came
+// from the keyboard with the cursor elsewhere
+func (g *Game) spawnClickText(value float64, crit bool) {
+	x, y := ebiten.CursorPosition()
+	fx, fy := float64(x), float64(y)
+	if !g.isMouseOverOrb(fx, fy) {
+		fx, fy = g.orbCenter()
+	}
+	if len(g.floatTexts) >= maxFloatTexts {
+		g.floatTexts = g.floatTexts[1:]
+	}
+	g.floatTexts = append(g.floatTexts, floatText{x: fx, y: fy, life: floatTextLifetime, value: value, crit: crit})
+}
+
+// Drift float texts upward and drop the ones that have faded out
+func (g *Game) updateFloatTexts(dt float64) {
+	kept := g.floatTexts[:0]
+	for _, ft := range g.floatTexts {
+		ft.life -= dt
+		if ft.life <= 0 {
+			continue
+		}
+		if !g.settings.ReduceMotion {
+			ft.y -= floatTextRise * dt
+		}
+		kept = append(kept, ft)
+	}
+	g.floatTexts = kept
+}
+
+func (g *Game) drawFloatTexts(screen *ebiten.Image) {
+	face := g.face(24)
+	critFace := g.face(34)
+	for _, ft := range g.floatTexts {
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(ft.x, ft.y)
+		op.PrimaryAlign = text.AlignCenter
+		op.SecondaryAlign = text.AlignCenter
+		if ft.crit {
+			op.ColorScale.ScaleWithColor(color.RGBA{255, 200, 40, 255})
+			op.ColorScale.ScaleAlpha(float32(ft.life / floatTextLifetime))
+			text.Draw(screen, fmt.Sprintf("LUCKY +%s", g.format(ft.value)), critFace, op)
+			continue
+		}
+		op.ColorScale.ScaleWithColor(color.RGBA{230, 230, 255, 255})
+		op.ColorScale.ScaleAlpha(float32(ft.life / floatTextLifetime))
+		text.Draw(screen, fmt.Sprintf("+%s", g.format(ft.value)), face, op)
+	}
+}
+
+// Center of the orb on screen. The orb sits in the middle of the orbits, so it
+// follows the orbit view's pan and zoom like they do.
+func (g *Game) orbCenter() (x, y float64) {
+	base := g.orbDiameter() / 2
+	cx, cy, _ := g.orbitView(float32(g.orbX+base), float32(g.orbY+base))
+	return float64(cx), float64(cy)
+}
+
+// Current orb radius at the orbit view's zoom: a slow pulse plus a brief swell
+// after each click
+func (g *Game) orbRadius() float64 {
+	scale := 1.0
+	if !g.settings.ReduceMotion {
+		scale += orbPulseAmount * math.Sin(g.animationTime*orbPulseSpeed)
+	}
+	if g.orbClicked {
+		scale += orbClickScale * float64(g.clickAnimation) / 10
+	}
+	return g.orbDiameter() / 2 * g.orbitZoom * scale
+}
+
+// Draw the glowing mana orb in the middle of the orbits
+func (g *Game) drawOrb(screen *ebiten.Image) {
+	x, y := g.orbCenter()
+	cx, cy := float32(x), float32(y)
+	radius := float32(g.orbRadius())
+	col := g.theme().orb
+
+	glow := col
+	glow.A = 60
+	vector.DrawFilledCircle(screen, cx, cy, radius*orbGlowScale, glow, g.antialias())
+	vector.DrawFilledCircle(screen, cx, cy, radius, col, g.antialias())
+
+	// Soft highlight towards the top left
+	highlight := color.RGBA{255, 255, 255, 70}
+	vector.DrawFilledCircle(screen, cx-radius*0.3, cy-radius*0.3, radius*0.35, highlight, g.antialias())
+}
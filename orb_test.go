@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+func TestIsMouseOverOrbFollowsOrbitView(t *testing.T) {
+	g := newTestGame(t)
+	g.Layout(screenWidth, screenHeight)
+	radius := g.orbDiameter() / 2
+	centerX, centerY := float64(g.width)/2, float64(g.height)/2
+
+	tests := []struct {
+		name       string
+		panX, panY float64
+		zoom       float64
+		x, y       float64
+		want       bool
+	}{
+		{"center", 0, 0, 1, centerX, centerY, true},
+		{"just inside", 0, 0, 1, centerX + radius*0.9, centerY, true},
+		{"just outside", 0, 0, 1, centerX + radius*1.1, centerY, false},
+		{"panned away", 300, 0, 1, centerX, centerY, false},
+		{"panned center", 300, -100, 1, centerX + 300, centerY - 100, true},
+		{"zoomed in", 0, 0, 2, centerX + radius*1.5, centerY, true},
+		{"zoomed out", 0, 0, 0.5, centerX + radius*0.75, centerY, false},
+	}
+	for _, tt := range tests {
+		g.orbitPanX, g.orbitPanY, g.orbitZoom = tt.panX, tt.panY, tt.zoom
+		if got := g.isMouseOverOrb(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: isMouseOverOrb(%v, %v) = %v, want %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestClickAllowed(t *testing.T) {
+	tests := []struct {
+		last, now, interval float64
+		want                bool
+	}{
+		{1, 1, 0, true},
+		{1, 1.05, 0.1, false},
+		{1, 1.1, 0.1, true},
+		{1, 1.5, 0.1, true},
+		{1, 1.2, 0.25, false},
+	}
+	for _, tt := range tests {
+		if got := clickAllowed(tt.last, tt.now, tt.interval); got != tt.want {
+			t.Errorf("clickAllowed(%v, %v, %v) = %v, want %v", tt.last, tt.now, tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestClickOrbMinInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval int     // Milliseconds
+		gap      float64 // Seconds between clicks
+		want     int64   // Clicks counted out of 10
+	}{
+		{"off", 0, 0, 10},
+		{"slower than the interval", 100, 0.2, 10},
+		{"faster than the interval", 100, 0.06, 5},
+		{"all in one tick", 100, 0, 1},
+	}
+	for _, tt := range tests {
+		g := newTestGame(t)
+		g.storageLevel = 5
+		g.settings.MinClickInterval = tt.interval
+		for range 10 {
+			g.clickOrb()
+			g.animationTime += tt.gap
+		}
+		if g.totalClicks != tt.want {
+			t.Errorf("%s: %d clicks counted, want %d", tt.name, g.totalClicks, tt.want)
+		}
+	}
+}
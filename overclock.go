@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	overclockSpeedFactor = 2.0  // Rotation speed factor while overclocked
+	overclockDuration    = 15.0 // Seconds the overclock lasts
+	overclockCooldown    = 90.0 // Seconds before it can be used again, counted from activation
+)
+
+// Overclock UI layout (bottom center, above the boost bar)
+const (
+	overclockBarBottom = 120 // Distance of the bar's top from the bottom edge
+	overclockLabelH    = 30  // Label above the bar, also clickable
+)
+
+// Overclock every generator if it's charged. Faster rotations complete more
+// rotations, so the multiplier gains speed up with them.
+func (g *Game) activateOverclock() bool {
+	if g.economyFrozen() || g.overclockCooldownLeft() > 0 {
+		return false
+	}
+	g.overclockUntil = g.animationTime + overclockDuration
+	g.overclockCooldownUntil = g.animationTime + overclockCooldown
+	g.logEvent("activated overclock")
+	return true
+}
+
+// Seconds left on the overclock. The timestamps are in animationTime, which
+// stands still while the game is paused, so a pause doesn't use up the overclock.
+func (g *Game) overclockLeft() float64 {
+	return max(0, g.overclockUntil-g.animationTime)
+}
+
+// Seconds until the overclock can be triggered again
+func (g *Game) overclockCooldownLeft() float64 {
+	return max(0, g.overclockCooldownUntil-g.animationTime)
+}
+
+// Rotation speed factor from the overclock
+func (g *Game) overclockSpeedMultiplier() float64 {
+	if g.overclockLeft() > 0 {
+		return overclockSpeedFactor
+	}
+	return 1
+}
+
+// Overclock bar and its label
+func (g *Game) overclockRect() (x, y, w, h int) {
+	return g.width/2 - boostBarWidth/2, g.height - overclockBarBottom - overclockLabelH, boostBarWidth, overclockLabelH + boostBarHeight
+}
+
+// Handle a click on the overclock bar, reporting whether it was consumed
+func (g *Game) handleOverclockClick(x, y int) bool {
+	bx, by, bw, bh := g.overclockRect()
+	if x >= bx && x <= bx+bw && y >= by && y <= by+bh {
+		g.activateOverclock()
+		return true
+	}
+	return false
+}
+
+func (g *Game) drawOverclock(screen *ebiten.Image) {
+	x := float32(g.width/2 - boostBarWidth/2)
+	barY := float32(g.height - overclockBarBottom)
+
+	var label string
+	var fill float32
+	barColor := color.RGBA{180, 120, 255, 255}
+	switch remaining, cooldown := g.overclockLeft(), g.overclockCooldownLeft(); {
+	case remaining > 0:
+		label = fmt.Sprintf("Overclocked: x%.0f speed for %.0fs", overclockSpeedFactor, remaining)
+		fill = float32(remaining / overclockDuration)
+		barColor = color.RGBA{255, 120, 200, 255}
+	case cooldown > 0:
+		label = fmt.Sprintf("Overclock recharging: %.0fs", cooldown)
+		fill = float32(1 - cooldown/overclockCooldown)
+	default:
+		label = "Overclock ready - click"
+		fill = 1
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(barY)-overclockLabelH)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, label, g.face(20), op)
+
+	vector.DrawFilledRect(screen, x, barY, boostBarWidth, boostBarHeight, color.RGBA{50, 50, 70, 255}, false)
+	vector.DrawFilledRect(screen, x, barY, boostBarWidth*fill, boostBarHeight, barColor, false)
+}
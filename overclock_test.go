@@ -0,0 +1,66 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestOverclockTimestamps(t *testing.T) {
+	g := newTestGame(t)
+	g.animationTime = 100
+	if !g.activateOverclock() {
+		t.Fatal("activateOverclock() failed")
+	}
+	if g.activateOverclock() {
+		t.Error("activated again during the cooldown")
+	}
+
+	tests := []struct {
+		at                    float64
+		speed, left, recharge float64
+	}{
+		{100, overclockSpeedFactor, overclockDuration, overclockCooldown},
+		{110, overclockSpeedFactor, overclockDuration - 10, overclockCooldown - 10},
+		{100 + overclockDuration, 1, 0, overclockCooldown - overclockDuration},
+		{100 + overclockCooldown, 1, 0, 0},
+	}
+	for _, tt := range tests {
+		g.animationTime = tt.at
+		if got := g.overclockSpeedMultiplier(); got != tt.speed {
+			t.Errorf("at %v: speed factor = %v, want %v", tt.at, got, tt.speed)
+		}
+		if got := g.overclockLeft(); got != tt.left {
+			t.Errorf("at %v: overclockLeft() = %v, want %v", tt.at, got, tt.left)
+		}
+		if got := g.overclockCooldownLeft(); got != tt.recharge {
+			t.Errorf("at %v: overclockCooldownLeft() = %v, want %v", tt.at, got, tt.recharge)
+		}
+	}
+	if !g.activateOverclock() {
+		t.Error("recharged overclock can't be activated")
+	}
+}
+
+func TestOverclockSaveKeepsTimeLeft(t *testing.T) {
+	g := newTestGame(t)
+	g.animationTime = 500
+	g.activateOverclock()
+	g.animationTime += 5
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := g.SaveGame(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new session starts its animationTime over
+	loaded := newTestGame(t)
+	loaded.animationTime = 3
+	if err := loaded.LoadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded.overclockLeft(), overclockDuration-5; got != want {
+		t.Errorf("overclockLeft() = %v, want %v", got, want)
+	}
+	if got, want := loaded.overclockCooldownLeft(), overclockCooldown-5; got != want {
+		t.Errorf("overclockCooldownLeft() = %v, want %v", got, want)
+	}
+}
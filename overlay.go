@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Performance overlay layout (bottom right corner)
+const (
+	perfOverlayMargin   = 10
+	perfOverlayLineSize = 14
+	perfOverlayLineH    = 18
+)
+
+// Toggle the performance overlay with F3
+func (g *Game) handlePerfOverlayKey() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		g.perfOverlay = !g.perfOverlay
+	}
+}
+
+// Draw frame rates, effect counts and the mana rate in the bottom right corner
+func (g *Game) drawPerfOverlay(screen *ebiten.Image) {
+	if !g.perfOverlay {
+		return
+	}
+	lines := []string{
+		fmt.Sprintf("FPS %.1f  TPS %.1f", ebiten.ActualFPS(), ebiten.ActualTPS()),
+		"FPS cap " + g.fpsCapStatus(),
+		fmt.Sprintf("Float texts %d/%d", len(g.floatTexts), maxFloatTexts),
+		fmt.Sprintf("Mana %s/sec", g.format(g.totalMultiplier)),
+	}
+
+	face := g.face(perfOverlayLineSize)
+	width := 0.0
+	for _, line := range lines {
+		w, _ := text.Measure(line, face, 0)
+		width = max(width, w)
+	}
+	boxW := float32(width) + perfOverlayMargin
+	boxH := float32(len(lines)*perfOverlayLineH) + perfOverlayMargin
+	x := float32(g.width) - boxW - perfOverlayMargin
+	y := float32(g.height) - boxH - perfOverlayMargin
+	vector.DrawFilledRect(screen, x, y, boxW, boxH, color.RGBA{0, 0, 0, 140}, false)
+
+	for i, line := range lines {
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(x)+perfOverlayMargin/2, float64(y)+perfOverlayMargin/2+float64(i*perfOverlayLineH))
+		op.ColorScale.ScaleWithColor(color.RGBA{200, 255, 200, 255})
+		text.Draw(screen, line, face, op)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Toggle the full pause with P. Unlike the economy soft pause this also stops
+// rotations and animations, so nothing jumps when play resumes.
+func (g *Game) handlePauseKey() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		g.paused = !g.paused
+		g.orbitDragging = false
+		g.logEvent("paused: %v", g.paused)
+	}
+}
+
+// Whether the game is held because the window lost focus and the player asked to
+// pause then. Ebiten normally stops calling Update itself, this covers platforms
+// where it keeps running.
+func (g *Game) blurPaused() bool {
+	return g.settings.PauseOnBlur && !ebiten.IsFocused()
+}
+
+func (g *Game) drawPauseOverlay(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, float32(g.width), float32(g.height), color.RGBA{0, 0, 0, 160}, false)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(g.width)/2, float64(g.height)/2)
+	op.PrimaryAlign = text.AlignCenter
+	op.SecondaryAlign = text.AlignCenter
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, "PAUSED", g.face(64), op)
+
+	op = &text.DrawOptions{}
+	op.GeoM.Translate(float64(g.width)/2, float64(g.height)/2+60)
+	op.PrimaryAlign = text.AlignCenter
+	op.SecondaryAlign = text.AlignCenter
+	op.ColorScale.ScaleWithColor(color.RGBA{200, 200, 200, 255})
+	text.Draw(screen, "Press P to resume", g.face(24), op)
+}
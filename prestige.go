@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	prestigeThreshold = 100000.0 // Mana needed before ascending
+	ascensionBonus    = 0.10     // +10% mana production per ascension point
+)
+
+// Prestige button layout (top right, above the generator panel)
+const (
+	prestigeButtonRight = 400 // Distance of the button's left edge from the right edge
+	prestigeButtonY     = 60
+	prestigeButtonW     = 370
+	prestigeButtonH     = 40
+)
+
+// Prestige confirmation dialog layout (centered)
+const (
+	prestigeDialogW       = 560
+	prestigeDialogH       = 340
+	prestigeDialogPadding = 20
+	prestigeDialogButtonW = 160
+	prestigeDialogButtonH = 40
+)
+
+// Put the generators and their per-generator state back to the start of a run,
+// sized by however many generators the config defines
+func (g *Game) resetGenerators() {
+	g.generators = g.config.generators()
+	g.rotationAngles = make([]float64, len(g.generators))
+	g.sharedRotationAngle = 0
+	g.multiplierHistories = make([]multiplierHistory, len(g.generators))
+	g.unlockAnimations = make([]float64, len(g.generators))
+	// Quantities picked per panel are a preference and survive a prestige
+	if len(g.panelQuantities) != len(g.generators) {
+		g.panelQuantities = make([]int, len(g.generators))
+	}
+}
+
+// Number of generators in play: the leading ones whose prestige requirement is
+// met. The config keeps gated tiers last, so they appear at the end of the list.
+func (g *Game) shownGenerators() int {
+	for i, generator := range g.generators {
+		if generator.minPrestige > g.prestiges {
+			return i
+		}
+	}
+	return len(g.generators)
+}
+
+// Ascension points a prestige with the given mana would award
+func ascensionPointsFor(mana float64) float64 {
+	if mana < prestigeThreshold {
+		return 0
+	}
+	return math.Sqrt(mana / 1000)
+}
+
+// Mana earned since the current run started, spent or not
+func (g *Game) runMana() float64 {
+	return max(0, g.lifetimeMana-g.runStartLifetime)
+}
+
+// Ascension points a prestige would award right now: none until the balance
+// reaches the threshold, then based on everything earned this run
+func (g *Game) prestigePoints() float64 {
+	if !g.canAfford(prestigeThreshold) {
+		return 0
+	}
+	return ascensionPointsFor(g.runMana())
+}
+
+// Prestige resets generators and mana in exchange for ascension points, which
+// permanently raise production. Generators lose their rotation gain upgrades with
+// their levels; retirement tokens and the token, lucky click, trickle and storage
+// upgrades are kept.
+func (g *Game) Prestige() bool {
+	points := g.prestigePoints()
+	if g.economyFrozen() || points == 0 {
+		return false
+	}
+
+	g.ascensionPoints = g.finite("ascensionPoints", g.ascensionPoints+points, g.ascensionPoints)
+	g.prestiges++
+	g.setMana(g.config.StartingMana)
+	g.runStartLifetime = g.lifetimeMana
+	g.resetGenerators()
+	g.logEvent("ascended for %.2f points", points)
+	g.calculateManaPerSec()
+	return true
+}
+
+// Global production factor from ascension points
+func (g *Game) ascensionMultiplier() float64 {
+	return 1 + ascensionBonus*g.ascensionPoints
+}
+
+// The prestige button shows up once the threshold is reached for the first time
+func (g *Game) showPrestige() bool {
+	return g.ascensionPoints > 0 || g.canAfford(prestigeThreshold)
+}
+
+// Handle a click on the prestige button, reporting whether it was consumed
+func (g *Game) handlePrestigeClick(x, y int) bool {
+	if !g.showPrestige() {
+		return false
+	}
+	prestigeButtonX := g.width - prestigeButtonRight
+	if x >= prestigeButtonX && x <= prestigeButtonX+prestigeButtonW &&
+		y >= prestigeButtonY && y <= prestigeButtonY+prestigeButtonH {
+		// A prestige wipes the run, so it always goes through the confirmation
+		if g.prestigePoints() > 0 {
+			g.prestigeConfirmOpen = true
+		}
+		return true
+	}
+	return false
+}
+
+// Top left corner of the prestige confirmation dialog
+func (g *Game) prestigeDialogOrigin() (x, y int) {
+	return (g.width - prestigeDialogW) / 2, (g.height - prestigeDialogH) / 2
+}
+
+// Confirm and cancel buttons at the bottom of the dialog
+func (g *Game) prestigeDialogButtons() (confirmX, cancelX, y int) {
+	originX, originY := g.prestigeDialogOrigin()
+	y = originY + prestigeDialogH - prestigeDialogPadding - prestigeDialogButtonH
+	confirmX = originX + prestigeDialogPadding
+	cancelX = originX + prestigeDialogW - prestigeDialogPadding - prestigeDialogButtonW
+	return confirmX, cancelX, y
+}
+
+// Handle a click while the confirmation is shown. Anything but the confirm
+// button cancels.
+func (g *Game) handlePrestigeConfirmClick(x, y int) {
+	g.prestigeConfirmOpen = false
+	confirmX, _, buttonY := g.prestigeDialogButtons()
+	if x >= confirmX && x <= confirmX+prestigeDialogButtonW && y >= buttonY && y <= buttonY+prestigeDialogButtonH {
+		g.Prestige()
+	}
+}
+
+// Confirm with Enter, cancel with Escape, and close the dialog if the prestige
+// became unavailable meanwhile
+func (g *Game) updatePrestigeConfirm() {
+	if !g.prestigeConfirmOpen {
+		return
+	}
+	switch {
+	case g.optionsOpen || g.prestigePoints() == 0 || inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		g.prestigeConfirmOpen = false
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		g.prestigeConfirmOpen = false
+		g.Prestige()
+	}
+}
+
+// Lines of the confirmation: what the prestige takes and what it gives
+func (g *Game) prestigePreview() []string {
+	points := g.prestigePoints()
+	levels := 0
+	for _, generator := range g.generators {
+		levels += generator.level
+	}
+	after := 1 + ascensionBonus*(g.ascensionPoints+points)
+	return []string{
+		"You lose:",
+		fmt.Sprintf("  %s mana", g.formatMana()),
+		fmt.Sprintf("  %d generator levels", levels),
+		fmt.Sprintf("  All rotation multipliers (%s/sec now)", g.format(g.totalMultiplier)),
+		"You gain:",
+		fmt.Sprintf("  %.2f ascension points for %s mana earned this run", points, g.format(g.runMana())),
+		fmt.Sprintf("  Ascension multiplier x%.2f -> x%.2f", g.ascensionMultiplier(), after),
+	}
+}
+
+func (g *Game) drawPrestigeConfirm(screen *ebiten.Image) {
+	// Dim the game behind the dialog
+	vector.DrawFilledRect(screen, 0, 0, float32(g.width), float32(g.height), color.RGBA{0, 0, 0, 160}, false)
+
+	originX, originY := g.prestigeDialogOrigin()
+	vector.DrawFilledRect(screen, float32(originX), float32(originY), prestigeDialogW, prestigeDialogH, color.RGBA{50, 30, 70, 240}, false)
+	vector.StrokeRect(screen, float32(originX), float32(originY), prestigeDialogW, prestigeDialogH, 2, color.RGBA{180, 130, 220, 255}, false)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(originX+prestigeDialogPadding), float64(originY+prestigeDialogPadding))
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, "Ascend?", g.face(24), op)
+
+	for i, line := range g.prestigePreview() {
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(originX+prestigeDialogPadding), float64(originY+prestigeDialogPadding+40+i*26))
+		op.ColorScale.ScaleWithColor(color.RGBA{210, 210, 210, 255})
+		text.Draw(screen, line, g.face(18), op)
+	}
+
+	confirmX, cancelX, buttonY := g.prestigeDialogButtons()
+	buttons := []struct {
+		x     int
+		label string
+		fill  color.RGBA
+	}{
+		{confirmX, "Ascend (Enter)", color.RGBA{110, 60, 130, 255}},
+		{cancelX, "Cancel (Esc)", color.RGBA{70, 70, 70, 255}},
+	}
+	for _, b := range buttons {
+		vector.DrawFilledRect(screen, float32(b.x), float32(buttonY), prestigeDialogButtonW, prestigeDialogButtonH, b.fill, false)
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(b.x)+12, float64(buttonY)+9)
+		op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+		text.Draw(screen, b.label, g.face(18), op)
+	}
+}
+
+func (g *Game) drawPrestige(screen *ebiten.Image) {
+	if !g.showPrestige() {
+		return
+	}
+
+	label := fmt.Sprintf("Ascension x%.2f (need %s mana)", g.ascensionMultiplier(), g.format(prestigeThreshold))
+	fill := color.RGBA{60, 60, 60, 255}
+	if points := g.prestigePoints(); points > 0 {
+		label = fmt.Sprintf("Ascend for %.2f points", points)
+		fill = color.RGBA{110, 60, 130, 255}
+	}
+	prestigeButtonX := float32(g.width - prestigeButtonRight)
+	vector.DrawFilledRect(screen, prestigeButtonX, prestigeButtonY, prestigeButtonW, prestigeButtonH, fill, false)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(prestigeButtonX)+10, prestigeButtonY+8)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, label, g.face(18), op)
+}
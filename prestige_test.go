@@ -0,0 +1,193 @@
+package main
+
+import "testing"
+
+func TestPrestigeClickAsksForConfirmation(t *testing.T) {
+	tests := []struct {
+		name      string
+		confirm   bool
+		ascending bool
+	}{
+		{"confirm", true, true},
+		{"cancel", false, false},
+	}
+	for _, tt := range tests {
+		g := newTestGame(t)
+		g.storageLevel = 5
+		g.addMana(4 * prestigeThreshold)
+		levels := g.generators[0].level
+
+		buttonX := g.width - prestigeButtonRight
+		if !g.handleTapAt(buttonX+1, prestigeButtonY+1) || !g.prestigeConfirmOpen {
+			t.Fatalf("%s: prestige button didn't open the confirmation", tt.name)
+		}
+		if g.ascensionPoints != 0 || g.generators[0].level != levels {
+			t.Fatalf("%s: prestige happened before it was confirmed", tt.name)
+		}
+
+		confirmX, cancelX, buttonY := g.prestigeDialogButtons()
+		x := cancelX
+		if tt.confirm {
+			x = confirmX
+		}
+		g.handleTapAt(x+1, buttonY+1)
+		if g.prestigeConfirmOpen {
+			t.Errorf("%s: dialog still open", tt.name)
+		}
+		if ascended := g.ascensionPoints > 0; ascended != tt.ascending {
+			t.Errorf("%s: ascended = %v, want %v", tt.name, ascended, tt.ascending)
+		}
+	}
+}
+
+func TestPrestigePreview(t *testing.T) {
+	g := newTestGame(t)
+	g.storageLevel = 5
+	g.setMana(0)
+	g.addMana(4 * prestigeThreshold)
+	preview := g.prestigePreview()
+	want := map[int]string{
+		5: "  20.00 ascension points for 400K mana earned this run",
+		6: "  Ascension multiplier x1.00 -> x3.00",
+	}
+	for i, line := range want {
+		if preview[i] != line {
+			t.Errorf("preview line %d = %q, want %q", i, preview[i], line)
+		}
+	}
+}
+
+func TestPrestigeGatedGenerators(t *testing.T) {
+	g := newTestGame(t)
+	gated := map[string]int{}
+	for _, generator := range g.generators {
+		if generator.minPrestige > 0 {
+			gated[generator.name] = generator.minPrestige
+		}
+	}
+	if len(gated) == 0 {
+		t.Fatal("the defaults have no prestige-only generators")
+	}
+
+	for prestiges := 0; prestiges <= 3; prestiges++ {
+		g.prestiges = prestiges
+		shown := g.shownGenerators()
+		for i, generator := range g.generators {
+			want := generator.minPrestige <= prestiges
+			if got := i < shown; got != want {
+				t.Errorf("%d prestiges: %s shown = %v, want %v", prestiges, generator.name, got, want)
+			}
+			// Hidden generators can't be bought either
+			g.setMana(1e12)
+			level := generator.level
+			g.buyBulk(i, 1)
+			if bought := g.generators[i].level > level; bought != want {
+				t.Errorf("%d prestiges: buying %s = %v, want %v", prestiges, generator.name, bought, want)
+			}
+		}
+	}
+}
+
+func TestPrestigeCountsPrestiges(t *testing.T) {
+	g := newTestGame(t)
+	hidden := g.shownGenerators()
+	g.storageLevel = 5
+	g.addMana(4 * prestigeThreshold)
+	if !g.Prestige() {
+		t.Fatal("Prestige() failed")
+	}
+	if g.prestiges != 1 {
+		t.Errorf("prestiges = %d, want 1", g.prestiges)
+	}
+	if g.shownGenerators() <= hidden {
+		t.Error("the first prestige didn't reveal a generator")
+	}
+}
+
+func TestAscensionPointsFor(t *testing.T) {
+	tests := []struct {
+		mana, want float64
+	}{
+		{0, 0},
+		{prestigeThreshold - 1, 0},
+		{prestigeThreshold, 10},
+		{400_000, 20},
+		{1e9, 1000},
+	}
+	for _, tt := range tests {
+		if got := ascensionPointsFor(tt.mana); got != tt.want {
+			t.Errorf("ascensionPointsFor(%v) = %v, want %v", tt.mana, got, tt.want)
+		}
+	}
+}
+
+func TestPrestigePointsFromRunEarnings(t *testing.T) {
+	g := newTestGame(t)
+	g.storageLevel = 5
+	g.setMana(0)
+	g.addMana(4 * prestigeThreshold)
+	// Spending doesn't reduce what the run earned
+	g.spend(2 * prestigeThreshold)
+	if got := g.prestigePoints(); got != 20 {
+		t.Errorf("prestigePoints() = %v, want 20", got)
+	}
+	// The balance alone counts for nothing without having earned it this run
+	g.runStartLifetime = g.lifetimeMana
+	if got := g.prestigePoints(); got != 0 {
+		t.Errorf("prestigePoints() = %v without earnings this run, want 0", got)
+	}
+}
+
+func TestPrestigeResets(t *testing.T) {
+	g := newTestGame(t)
+	g.storageLevel = 5
+	g.addMana(9 * prestigeThreshold)
+	g.setMana(9 * prestigeThreshold)
+	g.generators[1].level = 12
+	g.generators[1].cost = 999
+	g.generators[1].manaMultiplier = 3
+	g.rotationAngles[1] = 2
+	g.generators[1].upgradeLevel = 3
+	g.generators[1].multiplierGain = 0.05
+	g.retirementTokens = 4
+	g.tokenSpeedLevel = 2
+	g.critChanceLevel = 2
+	g.trickleLevel = 2
+	lifetime := g.lifetimeMana
+	points := g.prestigePoints()
+
+	if !g.Prestige() {
+		t.Fatal("Prestige() failed")
+	}
+	if g.ascensionPoints != points {
+		t.Errorf("ascensionPoints = %v, want %v", g.ascensionPoints, points)
+	}
+	if g.manaValue() != g.config.StartingMana {
+		t.Errorf("mana = %v, want the starting %v", g.manaValue(), g.config.StartingMana)
+	}
+	start := g.config.generators()
+	for i, generator := range g.generators {
+		if generator.level != start[i].level || generator.cost != start[i].cost || generator.manaMultiplier != 1 || g.rotationAngles[i] != 0 {
+			t.Errorf("%s wasn't reset: level %d, cost %v, multiplier %v", generator.name, generator.level, generator.cost, generator.manaMultiplier)
+		}
+		if generator.upgradeLevel != 0 || generator.multiplierGain != start[i].multiplierGain {
+			t.Errorf("%s kept its rotation gain upgrades: level %d, gain %v", generator.name, generator.upgradeLevel, generator.multiplierGain)
+		}
+	}
+	// Lifetime statistics and retirement tokens survive
+	if g.lifetimeMana != lifetime || g.retirementTokens != 4 {
+		t.Errorf("lifetime %v and tokens %d changed", g.lifetimeMana, g.retirementTokens)
+	}
+	// So do the global upgrades
+	if g.tokenSpeedLevel != 2 || g.critChanceLevel != 2 || g.trickleLevel != 2 || g.storageLevel != 5 {
+		t.Errorf("upgrades lost: token speed %d, luck %d, trickle %d, storage %d", g.tokenSpeedLevel, g.critChanceLevel, g.trickleLevel, g.storageLevel)
+	}
+	// The next run starts from zero
+	if g.runMana() != 0 || g.prestigePoints() != 0 {
+		t.Errorf("new run starts with %v mana earned", g.runMana())
+	}
+	// Too little mana refuses to prestige
+	if g.Prestige() {
+		t.Error("prestiged again right away")
+	}
+}
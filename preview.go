@@ -0,0 +1,46 @@
+package main
+
+import (
+	"maps"
+	"slices"
+)
+
+// Production rate in mana/sec and how fast it grows from rotation gains, in mana/sec
+// gained per second
+func (g *Game) productionOutlook() (rate, growth float64) {
+	for i := range g.generators {
+		if !g.generators[i].active() {
+			continue
+		}
+		speed := g.rotationSpeed(i)
+		if g.timerMode == timerModeShared {
+			speed = g.sharedRotationSpeed()
+		}
+		growth += speed * g.manaPerRotation(i)
+	}
+	return g.totalMultiplier, growth
+}
+
+// simulatePurchase returns a copy of the game with one more level of generator i
+// bought, leaving g untouched
+func (g *Game) simulatePurchase(i int) *Game {
+	sim := *g
+	sim.generators = slices.Clone(g.generators)
+	sim.unlockAnimations = slices.Clone(g.unlockAnimations)
+	sim.nonFiniteWarned = maps.Clone(g.nonFiniteWarned)
+	sim.events = nil
+
+	// Preview the level even when it isn't affordable yet
+	if cost := sim.nextLevelCost(i); !sim.canAfford(cost) {
+		sim.setMana(cost)
+	}
+	sim.buyGenerator(i)
+	return &sim
+}
+
+// Change in production rate and growth that buying a level of generator i would make
+func (g *Game) previewPurchase(i int) (rateDelta, growthDelta float64) {
+	rate, growth := g.productionOutlook()
+	simRate, simGrowth := g.simulatePurchase(i).productionOutlook()
+	return simRate - rate, simGrowth - growth
+}
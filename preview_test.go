@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPreviewPurchaseMatchesPurchase(t *testing.T) {
+	tests := []struct {
+		name  string
+		i     int
+		level int
+	}{
+		{"first level", 0, 0},
+		{"owned generator", 0, 5},
+		{"second generator", 1, 3},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.settings.BaselineTrickle = false
+		g.generators[0].level = 2
+		g.generators[tt.i].level = tt.level
+		g.setMana(1e6)
+		g.calculateManaPerSec()
+
+		rate, growth := g.productionOutlook()
+		rateDelta, growthDelta := g.previewPurchase(tt.i)
+		if g.manaValue() != 1e6 || g.generators[tt.i].level != tt.level || g.undo.remaining != 0 {
+			t.Fatalf("%s: preview changed the game: mana %v, level %d", tt.name, g.manaValue(), g.generators[tt.i].level)
+		}
+
+		if !g.buyGenerator(tt.i) {
+			t.Fatalf("%s: purchase failed", tt.name)
+		}
+		newRate, newGrowth := g.productionOutlook()
+		if got := newRate - rate; math.Abs(got-rateDelta) > 1e-9*max(1, newRate) {
+			t.Errorf("%s: rate rose by %v, preview said %v", tt.name, got, rateDelta)
+		}
+		if got := newGrowth - growth; math.Abs(got-growthDelta) > 1e-9*max(1, newGrowth) {
+			t.Errorf("%s: growth rose by %v, preview said %v", tt.name, got, growthDelta)
+		}
+	}
+}
+
+func TestPreviewPurchaseUnaffordable(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.setMana(0)
+	if _, growthDelta := g.previewPurchase(0); growthDelta <= 0 {
+		t.Errorf("unaffordable first level previews a growth change of %v", growthDelta)
+	}
+	if g.manaValue() != 0 || g.generators[0].level != 0 {
+		t.Errorf("preview changed the game: mana %v, level %d", g.manaValue(), g.generators[0].level)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const maxPromoCodeLength = 16
+
+// promoCode is a one-time bonus redeemable from the options menu
+type promoCode struct {
+	description string // Shown once the code is redeemed
+	redeem      func(g *Game)
+}
+
+// Recognized promo codes. Codes are matched case-insensitively and stored upper case.
+var promoCodes = map[string]promoCode{
+	"MAGICSTART": {
+		description: "+100 mana",
+		redeem:      func(g *Game) { g.addMana(100) },
+	},
+	"RECHARGE": {
+		description: "Boost recharged",
+		redeem:      func(g *Game) { g.boostCooldown = 0 },
+	},
+	"MIDNIGHT": {
+		description: "Midnight theme unlocked",
+		redeem:      func(g *Game) {}, // Unlocking is derived from redeemedCodes
+	},
+}
+
+// Redeem a promo code, returning a message describing the outcome
+func (g *Game) redeemPromoCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	promo, ok := promoCodes[code]
+	if !ok {
+		return "", fmt.Errorf("unknown code %q", code)
+	}
+	if slices.Contains(g.redeemedCodes, code) {
+		return "", fmt.Errorf("code %q was already redeemed", code)
+	}
+
+	promo.redeem(g)
+	g.redeemedCodes = append(g.redeemedCodes, code)
+	g.logEvent("redeemed %s", code)
+	return fmt.Sprintf("Redeemed %s: %s", code, promo.description), nil
+}
+
+// Start typing a promo code
+func (g *Game) beginPromoInput() {
+	g.promoEditing = true
+	g.promoInput = ""
+	g.promoMessage = ""
+}
+
+// Handle keyboard input while a promo code is being typed
+func (g *Game) updatePromoInput() {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if len(g.promoInput) < maxPromoCodeLength && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			g.promoInput += strings.ToUpper(string(r))
+		}
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && g.promoInput != "":
+		g.promoInput = g.promoInput[:len(g.promoInput)-1]
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		g.promoEditing = false
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		g.promoEditing = false
+		message, err := g.redeemPromoCode(g.promoInput)
+		if err != nil {
+			g.promoMessage = err.Error()
+		} else {
+			g.promoMessage = message
+		}
+	}
+}
+
+// Text shown in the options menu promo row
+func (g *Game) promoStatus() string {
+	switch {
+	case g.promoEditing:
+		return g.promoInput + "_ (Enter to redeem)"
+	case g.promoMessage != "":
+		return g.promoMessage
+	}
+	return "click to enter a code"
+}
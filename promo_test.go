@@ -0,0 +1,70 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRedeemPromoCode(t *testing.T) {
+	tests := []struct {
+		code  string
+		check func(g *Game) bool
+	}{
+		{"MAGICSTART", func(g *Game) bool { return g.manaValue() == 100 }},
+		{"magicstart", func(g *Game) bool { return g.manaValue() == 100 }},
+		{"RECHARGE", func(g *Game) bool { return g.boostCooldown == 0 }},
+		{" midnight ", func(g *Game) bool { return slices.Contains(g.themeNames(), "Midnight") }},
+	}
+	for _, tt := range tests {
+		g := newTestGame(t)
+		g.setMana(0)
+		g.boostCooldown = 60
+		if _, err := g.redeemPromoCode(tt.code); err != nil {
+			t.Errorf("redeemPromoCode(%q) error = %v", tt.code, err)
+			continue
+		}
+		if !tt.check(g) {
+			t.Errorf("redeemPromoCode(%q) didn't grant its bonus", tt.code)
+		}
+		if len(g.redeemedCodes) != 1 {
+			t.Errorf("redeemPromoCode(%q) recorded %v", tt.code, g.redeemedCodes)
+		}
+	}
+}
+
+func TestRedeemPromoCodeRejects(t *testing.T) {
+	g := newTestGame(t)
+	g.setMana(0)
+	if _, err := g.redeemPromoCode("NOSUCHCODE"); err == nil {
+		t.Error("unknown code was accepted")
+	}
+	if _, err := g.redeemPromoCode("MAGICSTART"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.redeemPromoCode("magicstart"); err == nil {
+		t.Error("code was redeemed twice")
+	}
+	if g.manaValue() != 100 {
+		t.Errorf("mana = %v, want the bonus granted once", g.manaValue())
+	}
+}
+
+func TestRedeemedCodesSurviveSave(t *testing.T) {
+	g := newTestGame(t)
+	if _, err := g.redeemPromoCode("MAGICSTART"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := g.SaveGame(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newTestGame(t)
+	if err := loaded.LoadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loaded.redeemPromoCode("MAGICSTART"); err == nil {
+		t.Error("code was redeemed again after loading the save")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// GIF recording limits, chosen to keep files shareable
+const (
+	gifFrameRate   = 10 // Captured frames per second
+	gifScale       = 4  // Screen pixels per GIF pixel along each axis
+	gifMaxDuration = 8  // Seconds before a recording stops on its own
+)
+
+// gifRecorder captures downscaled frames and writes them as an animated GIF
+type gifRecorder struct {
+	recording bool
+	frames    []*image.RGBA
+	sinceLast float64 // Seconds since the last captured frame
+	due       bool    // The next Draw should capture a frame
+	pixels    []byte  // Reused buffer for reading the screen
+	status    string  // Shown on screen after a recording
+}
+
+// Start or stop recording
+func (g *Game) toggleRecording() {
+	if g.recorder.recording {
+		g.stopRecording()
+		return
+	}
+	g.recorder.recording = true
+	g.recorder.frames = nil
+	g.recorder.sinceLast = 0
+	g.recorder.due = true // Capture the first frame right away
+	g.recorder.status = ""
+}
+
+// Stop recording and encode the frames in the background
+func (g *Game) stopRecording() {
+	g.recorder.recording = false
+	frames := g.recorder.frames
+	g.recorder.frames = nil
+	if len(frames) == 0 {
+		g.recorder.status = "Recording empty, nothing written"
+		return
+	}
+
+	path := fmt.Sprintf("magiclick-%s.gif", time.Now().Format("20060102-150405"))
+	g.recorder.status = "Writing " + path
+	go func() {
+		if err := writeGIF(path, frames); err != nil {
+			log.Printf("failed to write %s: %v", path, err)
+			return
+		}
+		log.Printf("wrote %s (%d frames)", path, len(frames))
+	}()
+}
+
+// Schedule frame captures at the GIF frame rate
+func (g *Game) updateRecorder(dt float64) {
+	r := &g.recorder
+	if !r.recording {
+		return
+	}
+	r.sinceLast += dt
+	if r.sinceLast >= 1.0/gifFrameRate {
+		r.sinceLast = 0
+		r.due = true
+	}
+}
+
+// Capture the screen if a frame is due; called from Draw before any debug overlays
+func (g *Game) captureFrame(screen *ebiten.Image) {
+	r := &g.recorder
+	if !r.recording || !r.due {
+		return
+	}
+	r.due = false
+
+	bounds := screen.Bounds()
+	if len(r.pixels) != 4*bounds.Dx()*bounds.Dy() {
+		r.pixels = make([]byte, 4*bounds.Dx()*bounds.Dy())
+	}
+	screen.ReadPixels(r.pixels)
+
+	// Nearest neighbor downscale
+	frame := image.NewRGBA(image.Rect(0, 0, bounds.Dx()/gifScale, bounds.Dy()/gifScale))
+	for y := 0; y < frame.Rect.Dy(); y++ {
+		for x := 0; x < frame.Rect.Dx(); x++ {
+			src := 4 * (y*gifScale*bounds.Dx() + x*gifScale)
+			copy(frame.Pix[frame.PixOffset(x, y):], r.pixels[src:src+4])
+		}
+	}
+	r.frames = append(r.frames, frame)
+
+	if len(r.frames) >= gifMaxDuration*gifFrameRate {
+		g.stopRecording()
+	}
+}
+
+// Quantize frames to a fixed palette and encode them as a looping GIF
+func writeGIF(path string, frames []*image.RGBA) error {
+	anim := &gif.GIF{}
+	for _, frame := range frames {
+		paletted := image.NewPaletted(frame.Bounds(), palette.Plan9)
+		draw.FloydSteinberg.Draw(paletted, frame.Bounds(), frame, image.Point{})
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, 100/gifFrameRate) // In 100ths of a second
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(f, anim); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (g *Game) drawRecorder(screen *ebiten.Image) {
+	status := g.recorder.status
+	if g.recorder.recording {
+		status = fmt.Sprintf("REC %.1fs / %ds (F10 to stop)", float64(len(g.recorder.frames))/gifFrameRate, gifMaxDuration)
+	}
+	if status == "" {
+		return
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(tokenShopX, 150)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 80, 80, 255})
+	text.Draw(screen, status, g.face(18), op)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Permanent bonuses bought with retirement tokens
+const (
+	tokenProductionBonus = 0.25 // +25% mana production per token spent
+	tokenSpeedBonus      = 0.10 // +10% rotation speed per token spent
+	tokenDiscountBonus   = 0.10 // -10% generator costs per token spent, compounding
+	minCostDiscount      = 0.25 // Costs never drop below this share of the full price
+)
+
+// tokenBonus identifies what a retirement token is spent on
+type tokenBonus int
+
+const (
+	tokenBonusProduction tokenBonus = iota
+	tokenBonusSpeed
+	tokenBonusDiscount
+)
+
+// Every bonus in shop order
+var tokenBonuses = []tokenBonus{tokenBonusProduction, tokenBonusSpeed, tokenBonusDiscount}
+
+// Token shop layout (top center)
+const (
+	tokenShopX       = 760
+	tokenShopY       = 20
+	tokenButtonY     = 60
+	tokenButtonW     = 200
+	tokenButtonH     = 40
+	tokenButtonSpace = 220
+)
+
+// Retire a maxed generator for a permanent token, removing it from active play.
+// A retired generator stops rotating and no longer contributes its multiplier.
+func (g *Game) retireGenerator(i int) bool {
+	generator := &g.generators[i]
+	if g.economyFrozen() || generator.retired || generator.level < maxGeneratorLevel {
+		return false
+	}
+
+	generator.retired = true
+	g.rotationAngles[i] = 0
+	g.retirementTokens++
+	g.logEvent("retired %s", generator.name)
+	g.calculateManaPerSec()
+	return true
+}
+
+// Spend one retirement token on a permanent bonus
+func (g *Game) spendToken(bonus tokenBonus) bool {
+	if g.economyFrozen() || g.retirementTokens <= 0 {
+		return false
+	}
+
+	switch bonus {
+	case tokenBonusProduction:
+		g.tokenProductionLevel++
+		g.logEvent("spent a token on production")
+	case tokenBonusSpeed:
+		g.tokenSpeedLevel++
+		g.logEvent("spent a token on speed")
+	case tokenBonusDiscount:
+		if g.globalCostDiscount() <= minCostDiscount {
+			return false
+		}
+		g.tokenDiscountLevel++
+		g.logEvent("spent a token on cheaper generators")
+	default:
+		return false
+	}
+	g.retirementTokens--
+	g.calculateManaPerSec()
+	return true
+}
+
+// Global production factor from spent tokens
+func (g *Game) tokenProductionMultiplier() float64 {
+	return 1 + tokenProductionBonus*float64(g.tokenProductionLevel)
+}
+
+// Global rotation speed factor from spent tokens
+func (g *Game) tokenSpeedMultiplier() float64 {
+	return 1 + tokenSpeedBonus*float64(g.tokenSpeedLevel)
+}
+
+// Factor applied to every generator's cost from spent tokens. It stacks on top of
+// each generator's own cost scaling and is floored so costs stay positive.
+func (g *Game) globalCostDiscount() float64 {
+	return max(minCostDiscount, math.Pow(1-tokenDiscountBonus, float64(g.tokenDiscountLevel)))
+}
+
+// The token shop only shows up once retirement is within reach
+func (g *Game) showTokenShop() bool {
+	if g.retirementTokens > 0 || g.tokenProductionLevel > 0 || g.tokenSpeedLevel > 0 || g.tokenDiscountLevel > 0 {
+		return true
+	}
+	for _, generator := range g.generators {
+		if generator.level >= maxGeneratorLevel {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle a click on the token shop buttons, reporting whether it was consumed
+func (g *Game) handleTokenClicks(x, y int) bool {
+	if !g.showTokenShop() {
+		return false
+	}
+	for _, bonus := range tokenBonuses {
+		buttonX := tokenShopX + int(bonus)*tokenButtonSpace
+		if x >= buttonX && x <= buttonX+tokenButtonW &&
+			y >= tokenButtonY && y <= tokenButtonY+tokenButtonH {
+			g.spendToken(bonus)
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) drawTokenShop(screen *ebiten.Image) {
+	if !g.showTokenShop() {
+		return
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(tokenShopX, tokenShopY)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 215, 100, 255})
+	text.Draw(screen, fmt.Sprintf("Retirement tokens: %d", g.retirementTokens), g.face(24), op)
+
+	labels := []string{
+		fmt.Sprintf("Production x%.2f", g.tokenProductionMultiplier()),
+		fmt.Sprintf("Speed x%.2f", g.tokenSpeedMultiplier()),
+		fmt.Sprintf("Costs x%.2f", g.globalCostDiscount()),
+	}
+	for i, label := range labels {
+		buttonX := float32(tokenShopX + i*tokenButtonSpace)
+		fill := color.RGBA{60, 60, 60, 255}
+		if g.retirementTokens > 0 {
+			fill = color.RGBA{110, 90, 30, 255}
+		}
+		vector.DrawFilledRect(screen, buttonX, tokenButtonY, tokenButtonW, tokenButtonH, fill, false)
+
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(buttonX)+10, tokenButtonY+8)
+		op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+		text.Draw(screen, label, g.face(18), op)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRetireGenerator(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(g *Game)
+		retire bool
+	}{
+		{"maxed", func(g *Game) { g.generators[1].level = maxGeneratorLevel }, true},
+		{"below max", func(g *Game) { g.generators[1].level = maxGeneratorLevel - 1 }, false},
+		{"already retired", func(g *Game) {
+			g.generators[1].level = maxGeneratorLevel
+			g.generators[1].retired = true
+		}, false},
+		{"economy paused", func(g *Game) {
+			g.generators[1].level = maxGeneratorLevel
+			g.economyPaused = true
+		}, false},
+	}
+	for _, tt := range tests {
+		g := newTestGame(t)
+		tt.setup(g)
+		g.rotationAngles[1] = 1.5
+		tokens := g.retirementTokens
+		if got := g.retireGenerator(1); got != tt.retire {
+			t.Errorf("%s: retireGenerator() = %v, want %v", tt.name, got, tt.retire)
+			continue
+		}
+		if !tt.retire {
+			continue
+		}
+		if g.retirementTokens != tokens+1 || !g.generators[1].retired || g.rotationAngles[1] != 0 {
+			t.Errorf("%s: tokens %d, retired %v, angle %v", tt.name, g.retirementTokens, g.generators[1].retired, g.rotationAngles[1])
+		}
+		// A retired generator no longer rotates
+		if g.generators[1].active() {
+			t.Errorf("%s: retired generator is still active", tt.name)
+		}
+	}
+}
+
+func TestSpendToken(t *testing.T) {
+	g := newTestGame(t)
+	if g.spendToken(tokenBonusProduction) {
+		t.Fatal("spent a token without having one")
+	}
+	g.retirementTokens = 3
+	for _, bonus := range tokenBonuses {
+		if !g.spendToken(bonus) {
+			t.Fatalf("spendToken(%v) failed", bonus)
+		}
+	}
+	if g.retirementTokens != 0 {
+		t.Errorf("%d tokens left, want 0", g.retirementTokens)
+	}
+	if got, want := g.tokenProductionMultiplier(), 1+tokenProductionBonus; got != want {
+		t.Errorf("tokenProductionMultiplier() = %v, want %v", got, want)
+	}
+	if got, want := g.tokenSpeedMultiplier(), 1+tokenSpeedBonus; got != want {
+		t.Errorf("tokenSpeedMultiplier() = %v, want %v", got, want)
+	}
+	if got, want := g.globalCostDiscount(), 1-tokenDiscountBonus; got != want {
+		t.Errorf("globalCostDiscount() = %v, want %v", got, want)
+	}
+}
+
+func TestGlobalCostDiscountFloor(t *testing.T) {
+	tests := []struct {
+		level int
+		want  float64
+	}{
+		{0, 1},
+		{1, 0.9},
+		{2, 0.81},
+		{14, minCostDiscount},
+		{100, minCostDiscount},
+	}
+	for _, tt := range tests {
+		g := &Game{tokenDiscountLevel: tt.level}
+		if got := g.globalCostDiscount(); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("level %d: globalCostDiscount() = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+
+	// Tokens can't push the discount past the floor
+	g := newTestGame(t)
+	g.tokenDiscountLevel = 14
+	g.retirementTokens = 1
+	if g.spendToken(tokenBonusDiscount) || g.retirementTokens != 1 {
+		t.Error("spent a token on a discount at the floor")
+	}
+}
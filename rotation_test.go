@@ -0,0 +1,219 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestTimeToNextGain(t *testing.T) {
+	tests := []struct {
+		name            string
+		angle           float64
+		rotationsPerSec float64
+		want            float64
+		ok              bool
+	}{
+		{"full rotation at 1/s", 0, 1, 1, true},
+		{"half way at 1/s", math.Pi, 1, 0.5, true},
+		{"quarter left at 2/s", 1.5 * math.Pi, 2, 0.125, true},
+		{"full rotation at 0.25/s", 0, 0.25, 4, true},
+		{"not rotating", math.Pi, 0, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := timeToNextGain(tt.angle, tt.rotationsPerSec)
+		if ok != tt.ok || math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s: timeToNextGain(%v, %v) = %v, %v, want %v, %v", tt.name, tt.angle, tt.rotationsPerSec, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNextGainIn(t *testing.T) {
+	g := newIdleTestGame(t)
+	if _, ok := g.nextGainIn(0); ok {
+		t.Error("level 0 generator reports a next gain")
+	}
+
+	g.generators[0].level = 1
+	g.rotationAngles[0] = math.Pi
+	got, ok := g.nextGainIn(0)
+	if want := 0.5 / g.rotationSpeed(0); !ok || math.Abs(got-want) > 1e-12 {
+		t.Errorf("nextGainIn(0) = %v, %v, want %v", got, ok, want)
+	}
+}
+
+func TestRotationProductionGain(t *testing.T) {
+	tests := []struct {
+		name                                    string
+		raw, multiplier, gain, floor, threshold float64
+		want                                    float64
+	}{
+		// 100 = 2 (this multiplier) * 50 (everything else), 2.01 * 50 = 100.5
+		{"uncapped", 100, 2, 0.01, 0, 0, 0.5},
+		{"scales with the other factors", 1000, 2, 0.01, 0, 0, 5},
+		{"no multiplier yet", 100, 0, 0.01, 0, 0, 0},
+		{"below the floor", 1, 1, 0.01, 5, 0, 0},
+		{"crossing the floor", 100, 1, 0.5, 120, 0, 30},
+		{"below the soft cap", 100, 2, 0.01, 0, 1000, 0.5},
+		{"past the soft cap", 2000, 2, 0.01, 0, 1000, 1000 * math.Log(2010.0/2000)},
+	}
+	for _, tt := range tests {
+		got := rotationProductionGain(tt.raw, tt.multiplier, tt.gain, tt.floor, tt.threshold)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: rotationProductionGain() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestManaPerRotation(t *testing.T) {
+	g := newIdleTestGame(t)
+	if got := g.manaPerRotation(0); got != 0 {
+		t.Errorf("level 0 generator gains %v per rotation", got)
+	}
+
+	// The derived gain is what production actually rises by after a rotation
+	g.settings.BaselineTrickle = false
+	g.generators[0].level = 1
+	g.calculateManaPerSec()
+	before, want := g.totalMultiplier, g.manaPerRotation(0)
+	if want <= 0 {
+		t.Fatalf("level 1 generator gains %v per rotation", want)
+	}
+	g.addRotationGains(0, 1)
+	g.calculateManaPerSec()
+	if got := g.totalMultiplier - before; math.Abs(got-want) > 1e-9*max(1, before) {
+		t.Errorf("a rotation added %v to production, manaPerRotation = %v", got, want)
+	}
+}
+
+func TestCompletedRotations(t *testing.T) {
+	const turn = 2 * math.Pi
+	tests := []struct {
+		name               string
+		oldAngle, newAngle float64
+		want               float64
+	}{
+		{"within a rotation", 0.1, 3, 0},
+		{"crossing one boundary", turn - 0.1, turn + 0.1, 1},
+		{"landing on the boundary", 1, turn, 1},
+		{"several in one tick", 0.5, 5*turn + 0.2, 5},
+		{"from the boundary", 0, turn - 0.01, 0},
+		{"not moving", 2, 2, 0},
+	}
+	for _, tt := range tests {
+		if got := completedRotations(tt.oldAngle, tt.newAngle); got != tt.want {
+			t.Errorf("%s: completedRotations(%v, %v) = %v, want %v", tt.name, tt.oldAngle, tt.newAngle, got, tt.want)
+		}
+	}
+}
+
+func TestHighSpeedRotationGains(t *testing.T) {
+	// Over 60 rotations per second several boundaries pass in each 60 TPS tick
+	for _, mode := range []timerMode{timerModeIndependent, timerModeShared} {
+		g := newIdleTestGame(t)
+		g.timerMode = mode
+		g.generators[0].level = maxGeneratorLevel
+		g.generators[0].speedPerLevel = 1.237
+		speed := g.rotationSpeed(0)
+		if speed <= 60 {
+			t.Fatalf("generator turns only %v times per second", speed)
+		}
+		start, gain := g.generators[0].manaMultiplier, g.generators[0].multiplierGain
+		for range 60 {
+			g.step(1.0 / 60)
+		}
+		want := start + gain*math.Floor(speed)
+		if got := g.generators[0].manaMultiplier; math.Abs(got-want) > 1e-9 {
+			t.Errorf("%v: multiplier = %v after one second at %v rotations/sec, want %v", mode, got, speed, want)
+		}
+	}
+}
+
+func TestSharedAndIndependentAccrual(t *testing.T) {
+	const ticks = 630 // 10.5 seconds at 60 TPS
+	tests := []struct {
+		mode  timerMode
+		gains [2]float64 // Rotations completed by the 1/sec and the 3/sec generator
+	}{
+		// Each generator turns at its own speed
+		{timerModeIndependent, [2]float64{10, 31}},
+		// Both follow the shared timer at their average speed of 2/sec
+		{timerModeShared, [2]float64{21, 21}},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.timerMode = tt.mode
+		for i, speed := range []float64{1, 3} {
+			g.generators[i].level = 1
+			g.generators[i].speedPerLevel = speed
+		}
+		start := [2]float64{g.generators[0].manaMultiplier, g.generators[1].manaMultiplier}
+		var gainTicks [2][]int
+		for tick := range ticks {
+			before := [2]float64{g.generators[0].manaMultiplier, g.generators[1].manaMultiplier}
+			g.step(1.0 / 60)
+			for i := range 2 {
+				if g.generators[i].manaMultiplier != before[i] {
+					gainTicks[i] = append(gainTicks[i], tick)
+				}
+			}
+		}
+
+		for i := range 2 {
+			gen := g.generators[i]
+			want := start[i] + gen.multiplierGain*tt.gains[i]
+			if math.Abs(gen.manaMultiplier-want) > 1e-9 {
+				t.Errorf("%v: generator %d multiplier = %v, want %v", tt.mode, i, gen.manaMultiplier, want)
+			}
+		}
+		// Shared timers gain on the same ticks, independent ones don't
+		if synced := slices.Equal(gainTicks[0], gainTicks[1]); synced != (tt.mode == timerModeShared) {
+			t.Errorf("%v: gains synchronized = %v, ticks %v and %v", tt.mode, synced, gainTicks[0], gainTicks[1])
+		}
+	}
+}
+
+func TestSharedTimerKeepsTotalRate(t *testing.T) {
+	// Over a long run both variants award the same number of rotations in total
+	total := func(mode timerMode) float64 {
+		g := newIdleTestGame(t)
+		g.timerMode = mode
+		for i, speed := range []float64{0.7, 2.9, 1.3} {
+			g.generators[i].level = 1
+			g.generators[i].speedPerLevel = speed
+		}
+		start := g.generators[0].manaMultiplier
+		for range 60 * 100 {
+			g.step(1.0 / 60)
+		}
+		rotations := 0.0
+		for i := range 3 {
+			rotations += (g.generators[i].manaMultiplier - start) / g.generators[i].multiplierGain
+		}
+		return rotations
+	}
+	independent, shared := total(timerModeIndependent), total(timerModeShared)
+	// Each generator can be at most one rotation short of its exact share
+	if math.Abs(independent-shared) > 3 {
+		t.Errorf("%v rotations with independent timers, %v with a shared one", independent, shared)
+	}
+}
+
+func TestEconomyVariantOption(t *testing.T) {
+	g := newTestGame(t)
+	for _, row := range optionRows {
+		if row.label != "Economy variant" {
+			continue
+		}
+		row.next(g)
+		if g.timerMode != timerModeShared || row.value(g) != "shared timers" {
+			t.Errorf("after one click: %v, shown as %q", g.timerMode, row.value(g))
+		}
+		row.next(g)
+		if g.timerMode != timerModeIndependent {
+			t.Errorf("after two clicks: %v", g.timerMode)
+		}
+		return
+	}
+	t.Fatal("no economy variant option")
+}
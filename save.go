@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Bump saveVersion whenever older builds can no longer read the save correctly:
+//
+//	1: the original JSON save
+//	2: mana and manaPerSec as decimal strings, optionally gzipped
+const (
+	saveVersion             = 2           // Version written by this build
+	defaultSavePath         = "save.json" // Default save location
+	defaultAutosaveInterval = 30          // Seconds between autosaves
+)
+
+// ErrSaveTooNew is returned when a save was written by a newer build of the game.
+// Such a save must never be overwritten since this build can't represent its data.
+var ErrSaveTooNew = errors.New("save file was written by a newer version of the game")
+
+type saveData struct {
+	Version         int             `json:"version"`
+	Mana            BigNumber       `json:"mana"`
+	ManaPerSec      BigNumber       `json:"manaPerSec"` // Informational only since it's recalculated on load
+	SharedAngle     float64         `json:"sharedRotationAngle,omitempty"`
+	Variant         string          `json:"variant,omitempty"`
+	Difficulty      string          `json:"difficulty,omitempty"`
+	Tokens          int             `json:"retirementTokens,omitempty"`
+	TokenProduction int             `json:"tokenProduction,omitempty"`
+	TokenSpeed      int             `json:"tokenSpeed,omitempty"`
+	TokenDiscount   int             `json:"tokenDiscount,omitempty"`
+	CritChance      int             `json:"critChance,omitempty"`
+	CritMultiplier  int             `json:"critMultiplier,omitempty"`
+	BoostRemaining  float64         `json:"boostRemaining,omitempty"`
+	BoostCooldown   float64         `json:"boostCooldown,omitempty"`
+	Overclock       float64         `json:"overclockRemaining,omitempty"`
+	OverclockCool   float64         `json:"overclockCooldown,omitempty"`
+	SurgeRemaining  float64         `json:"surgeRemaining,omitempty"`
+	RedeemedCodes   []string        `json:"redeemedCodes,omitempty"`
+	TrickleLevel    int             `json:"trickleLevel,omitempty"`
+	AscensionPoints float64         `json:"ascensionPoints,omitempty"`
+	Prestiges       int             `json:"prestiges,omitempty"`
+	LifetimeMana    float64         `json:"lifetimeMana,omitempty"`
+	RunStart        float64         `json:"runStartLifetimeMana,omitempty"`
+	TotalClicks     int64           `json:"totalClicks,omitempty"`
+	PlayTime        float64         `json:"playTime,omitempty"`
+	StorageLevel    int             `json:"storageLevel,omitempty"`
+	Generators      []generatorSave `json:"generators"`
+	RotationAngles  []float64       `json:"rotationAngles"`
+}
+
+type generatorSave struct {
+	Cost           float64 `json:"cost"`
+	Level          int     `json:"level"`
+	ManaMultiplier float64 `json:"manaMultiplier"`
+	Retired        bool    `json:"retired,omitempty"`
+	Timer          int     `json:"timer,omitempty"`
+	MultiplierGain float64 `json:"multiplierGain,omitempty"`
+	UpgradeLevel   int     `json:"upgradeLevel,omitempty"`
+	Disabled       bool    `json:"disabled,omitempty"`
+}
+
+// SaveGame writes the current game state to path as JSON
+func (g *Game) SaveGame(path string) error {
+	// Refuse to clobber a save from a newer build
+	if version, err := readSaveVersion(path); err == nil && version > saveVersion {
+		return fmt.Errorf("%s: %w (save version %d, supported %d)", path, ErrSaveTooNew, version, saveVersion)
+	}
+
+	// Never persist the maxed out state of a running stress test
+	data := g.snapshot()
+	if g.bench.running {
+		data = g.bench.saved
+	}
+
+	// Never persist NaN/Inf, they would corrupt the save for good
+	if err := data.validate(); err != nil {
+		return fmt.Errorf("%s: refusing to save: %w", path, err)
+	}
+
+	b, err := encodeSave(data, g.settings.CompressSaves)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// Save every autosaveInterval seconds so a crash loses little progress
+func (g *Game) updateAutosave(dt float64) {
+	if g.autosaveInterval <= 0 {
+		return
+	}
+	g.autosaveTimer += dt
+	if g.autosaveTimer < float64(g.autosaveInterval) {
+		return
+	}
+	g.autosaveTimer = 0
+	if err := g.SaveGame(g.savePath); err != nil {
+		log.Printf("failed to autosave: %v", err)
+	}
+}
+
+// encodeSave serializes data as readable JSON, or gzipped JSON when compress is set
+func encodeSave(data saveData, compress bool) ([]byte, error) {
+	if !compress {
+		return json.MarshalIndent(data, "", "  ")
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeSave returns the JSON of a save, decompressing it if it was gzipped
+func decodeSave(b []byte) ([]byte, error) {
+	// Gzip streams start with a fixed magic number that JSON never does
+	if !bytes.HasPrefix(b, []byte{0x1f, 0x8b}) {
+		return b, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// readSaveFile reads the save at path in either format
+func readSaveFile(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if b, err = decodeSave(b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+// snapshot captures the persistent game state
+func (g *Game) snapshot() saveData {
+	data := saveData{
+		Version:         saveVersion,
+		Mana:            g.mana,
+		ManaPerSec:      g.manaPerSec,
+		SharedAngle:     g.sharedRotationAngle,
+		Variant:         g.timerMode.String(),
+		Difficulty:      g.difficulty.name,
+		Tokens:          g.retirementTokens,
+		TokenProduction: g.tokenProductionLevel,
+		TokenSpeed:      g.tokenSpeedLevel,
+		TokenDiscount:   g.tokenDiscountLevel,
+		CritChance:      g.critChanceLevel,
+		CritMultiplier:  g.critMultiplierLevel,
+		BoostRemaining:  g.boostRemaining,
+		BoostCooldown:   g.boostCooldown,
+		Overclock:       g.overclockLeft(),
+		OverclockCool:   g.overclockCooldownLeft(),
+		SurgeRemaining:  g.surgeRemaining,
+		RedeemedCodes:   slices.Clone(g.redeemedCodes),
+		TrickleLevel:    g.trickleLevel,
+		AscensionPoints: g.ascensionPoints,
+		Prestiges:       g.prestiges,
+		LifetimeMana:    g.lifetimeMana,
+		RunStart:        g.runStartLifetime,
+		TotalClicks:     g.totalClicks,
+		PlayTime:        g.playTime,
+		StorageLevel:    g.storageLevel,
+		RotationAngles:  slices.Clone(g.rotationAngles),
+	}
+	for _, generator := range g.generators {
+		data.Generators = append(data.Generators, generatorSave{
+			Cost:           generator.cost,
+			Level:          generator.level,
+			ManaMultiplier: generator.manaMultiplier,
+			Retired:        generator.retired,
+			Timer:          generator.timer,
+			MultiplierGain: generator.multiplierGain,
+			UpgradeLevel:   generator.upgradeLevel,
+			Disabled:       !generator.enabled,
+		})
+	}
+	return data
+}
+
+// LoadGame restores the game state from the save at path
+func (g *Game) LoadGame(path string) error {
+	b, err := readSaveFile(path)
+	if err != nil {
+		return err
+	}
+
+	var data saveData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	// A newer save may contain data this build would silently drop
+	if data.Version > saveVersion {
+		return fmt.Errorf("%s: %w (save version %d, supported %d)", path, ErrSaveTooNew, data.Version, saveVersion)
+	}
+
+	if err := data.validate(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := g.restore(data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// restore replaces the persistent game state with data
+func (g *Game) restore(data saveData) error {
+	mode := g.timerMode
+	if data.Variant != "" {
+		var err error
+		if mode, err = parseTimerMode(data.Variant); err != nil {
+			return err
+		}
+	}
+	difficulty := g.difficulty
+	if data.Difficulty != "" {
+		var err error
+		if difficulty, err = parseDifficulty(data.Difficulty); err != nil {
+			return err
+		}
+	}
+
+	g.mana = data.Mana
+	g.sharedRotationAngle = data.SharedAngle
+	g.timerMode = mode
+	g.difficulty = difficulty
+	g.retirementTokens = data.Tokens
+	g.tokenProductionLevel = data.TokenProduction
+	g.tokenSpeedLevel = data.TokenSpeed
+	g.tokenDiscountLevel = data.TokenDiscount
+	g.critChanceLevel = data.CritChance
+	g.critMultiplierLevel = data.CritMultiplier
+	g.boostRemaining = data.BoostRemaining
+	g.boostCooldown = data.BoostCooldown
+	// The save holds seconds left, animationTime restarts with every session
+	g.overclockUntil = g.animationTime + data.Overclock
+	g.overclockCooldownUntil = g.animationTime + data.OverclockCool
+	g.surgeRemaining = data.SurgeRemaining
+	g.redeemedCodes = data.RedeemedCodes
+	g.trickleLevel = data.TrickleLevel
+	g.ascensionPoints = data.AscensionPoints
+	g.prestiges = data.Prestiges
+	// Saves from before the count have prestiged at least once if they have points
+	if g.prestiges == 0 && g.ascensionPoints > 0 {
+		g.prestiges = 1
+	}
+	g.lifetimeMana = data.LifetimeMana
+	g.runStartLifetime = data.RunStart
+	// Saves from before the run start was kept only know the current balance
+	// belongs to this run once they have prestiged
+	if data.RunStart == 0 && data.AscensionPoints > 0 {
+		g.runStartLifetime = max(0, g.lifetimeMana-clampFinite(data.Mana.Float64(), 0))
+	}
+	g.totalClicks = data.TotalClicks
+	g.playTime = data.PlayTime
+	// Saves from before the mana cap get enough storage for their mana
+	g.storageLevel = max(data.StorageLevel, storageLevelFor(data.Mana))
+	g.baselineProduction = trickleBaseline(g.trickleLevel)
+	for i := range g.generators {
+		if i >= len(data.Generators) {
+			break
+		}
+		g.generators[i].cost = data.Generators[i].Cost
+		g.generators[i].level = data.Generators[i].Level
+		g.generators[i].manaMultiplier = data.Generators[i].ManaMultiplier
+		g.generators[i].retired = data.Generators[i].Retired
+		g.generators[i].timer = data.Generators[i].Timer
+		g.generators[i].upgradeLevel = data.Generators[i].UpgradeLevel
+		g.generators[i].enabled = !data.Generators[i].Disabled
+		// Saves from before upgrades have no gain stored
+		if gain := data.Generators[i].MultiplierGain; gain > 0 {
+			g.generators[i].multiplierGain = gain
+		}
+	}
+	copy(g.rotationAngles, data.RotationAngles)
+
+	// Keep totalMultiplier consistent with the restored generators
+	g.calculateManaPerSec()
+
+	return nil
+}
+
+// readSaveVersion reports the version of the save at path without loading it
+func readSaveVersion(path string) (int, error) {
+	b, err := readSaveFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(b, &header); err != nil {
+		return 0, err
+	}
+	return header.Version, nil
+}
+
+// downgradeSavePath returns a sibling path used when the main save is too new,
+// e.g. "save.json" becomes "save.downgrade.json"
+func downgradeSavePath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".downgrade" + ext
+}
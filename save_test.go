@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveRoundTrip(t *testing.T) {
+	g := newTestGame(t)
+	g.setMana(1234.5)
+	g.storageLevel = 2
+	g.generators[1].level = 7
+	g.generators[1].cost = 321
+	g.ascensionPoints = 3
+	g.prestiges = 2
+	g.lifetimeMana = 98765
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := g.SaveGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := readSaveVersion(path); err != nil || version != saveVersion {
+		t.Fatalf("readSaveVersion() = %d, %v, want %d", version, err, saveVersion)
+	}
+
+	loaded := newTestGame(t)
+	if err := loaded.LoadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.manaValue() != 1234.5 || loaded.storageLevel != 2 || loaded.ascensionPoints != 3 || loaded.lifetimeMana != 98765 || loaded.prestiges != 2 {
+		t.Errorf("loaded mana %v, storage %d, points %v, lifetime %v",
+			loaded.manaValue(), loaded.storageLevel, loaded.ascensionPoints, loaded.lifetimeMana)
+	}
+	if gen := loaded.generators[1]; gen.level != 7 || gen.cost != 321 {
+		t.Errorf("loaded generator level %d cost %v, want 7 and 321", gen.level, gen.cost)
+	}
+}
+
+func TestLoadVersion1Save(t *testing.T) {
+	// Version 1 saved mana as a plain JSON number
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1, "mana": 250.5, "manaPerSec": 150}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g := newTestGame(t)
+	if err := g.LoadGame(path); err != nil {
+		t.Fatal(err)
+	}
+	if g.manaValue() != 250.5 {
+		t.Errorf("mana = %v, want 250.5", g.manaValue())
+	}
+}
+
+func TestLoadSaveTooNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "mana": "5e0"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g := newTestGame(t)
+	g.setMana(10)
+	if err := g.LoadGame(path); !errors.Is(err, ErrSaveTooNew) {
+		t.Fatalf("LoadGame() error = %v, want ErrSaveTooNew", err)
+	}
+	if g.manaValue() != 10 {
+		t.Errorf("a rejected save changed mana to %v", g.manaValue())
+	}
+}
+
+func TestSaveRefusesNewerSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	newer := []byte(`{"version": 99, "mana": "5e0"}`)
+	if err := os.WriteFile(path, newer, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g := newTestGame(t)
+	if err := g.SaveGame(path); !errors.Is(err, ErrSaveTooNew) {
+		t.Fatalf("SaveGame() error = %v, want ErrSaveTooNew", err)
+	}
+	if b, err := os.ReadFile(path); err != nil || string(b) != string(newer) {
+		t.Errorf("newer save was modified: %s, %v", b, err)
+	}
+}
+
+func TestLoadSaveFallsBackForNewerSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "save.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "mana": "5e0"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g := newGameWithPaths(0, path, filepath.Join(dir, "settings.json"), WithoutAudio())
+	if want := filepath.Join(dir, "save.downgrade.json"); g.savePath != want {
+		t.Fatalf("savePath = %q, want %q", g.savePath, want)
+	}
+	if err := g.SaveGame(g.savePath); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := readSaveVersion(path); err != nil || version != 99 {
+		t.Errorf("newer save now has version %d, %v", version, err)
+	}
+}
+
+func TestDowngradeSavePath(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"save.json", "save.downgrade.json"},
+		{"save-slot2.json", "save-slot2.downgrade.json"},
+		{filepath.Join("dir", "save.json"), filepath.Join("dir", "save.downgrade.json")},
+		{"save", "save.downgrade"},
+	}
+	for _, tt := range tests {
+		if got := downgradeSavePath(tt.path); got != tt.want {
+			t.Errorf("downgradeSavePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSaveFormatsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+		prefix   []byte
+	}{
+		{"readable JSON", false, []byte("{")},
+		{"gzipped JSON", true, []byte{0x1f, 0x8b}},
+	}
+	for _, tt := range tests {
+		g := newTestGame(t)
+		g.settings.CompressSaves = tt.compress
+		g.setMana(4321)
+		g.generators[0].level = 3
+		path := filepath.Join(t.TempDir(), "save.json")
+		if err := g.SaveGame(path); err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(b, tt.prefix) {
+			t.Errorf("%s: save starts with %q", tt.name, b[:min(len(b), 4)])
+		}
+		if version, err := readSaveVersion(path); err != nil || version != saveVersion {
+			t.Errorf("%s: readSaveVersion() = %d, %v", tt.name, version, err)
+		}
+
+		// Loading detects the format regardless of the setting
+		loaded := newTestGame(t)
+		loaded.settings.CompressSaves = !tt.compress
+		if err := loaded.LoadGame(path); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if loaded.manaValue() != 4321 || loaded.generators[0].level != 3 {
+			t.Errorf("%s: loaded mana %v, level %d", tt.name, loaded.manaValue(), loaded.generators[0].level)
+		}
+	}
+}
+
+func TestDecodeSaveCorruptGzip(t *testing.T) {
+	if _, err := decodeSave([]byte{0x1f, 0x8b, 0, 1, 2}); err == nil {
+		t.Error("truncated gzip data was decoded")
+	}
+}
+
+func TestCompressSavesPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	s := defaultSettings()
+	s.CompressSaves = !s.CompressSaves
+	if err := s.save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.CompressSaves != s.CompressSaves {
+		t.Errorf("compressSaves = %v after reloading, want %v", loaded.CompressSaves, s.CompressSaves)
+	}
+}
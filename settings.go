@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const defaultSettingsPath = "settings.json" // Player preferences, kept apart from progress
+
+// Settings holds player preferences that persist across sessions
+type Settings struct {
+	FPSCap            int         `json:"fpsCap"`            // Ticks per second, 0 means uncapped
+	Theme             string      `json:"theme"`             // Name of the active color theme
+	TitleMode         string      `json:"titleMode"`         // What the window title shows, see titleModes
+	TitleInterval     int         `json:"titleInterval"`     // Seconds between window title updates
+	Sparklines        bool        `json:"sparklines"`        // Show multiplier history on generator panels
+	GlowIntensity     int         `json:"glowIntensity"`     // Alpha of the orbit indicator glow, 0-255
+	Accrual           accrualMode `json:"accrual"`           // How game time advances per tick
+	ManaRounding      string      `json:"manaRounding"`      // Rounding of the mana readout, see manaRoundings
+	BuyRepeatRate     int         `json:"buyRepeatRate"`     // Purchases per second while a buy key is held
+	ReduceMotion      bool        `json:"reduceMotion"`      // Skip decorative animations
+	MaxBuyPerAction   int         `json:"maxBuyPerAction"`   // Levels a single purchase action may buy, 0 means unlimited
+	BaselineTrickle   bool        `json:"baselineTrickle"`   // Keep production at or above the baseline trickle
+	ShowNextGain      bool        `json:"showNextGain"`      // Show time until each generator's next multiplier gain
+	CircleQuality     string      `json:"circleQuality"`     // Smoothness of circles and arcs, see circleQualities
+	OrbitPaths        bool        `json:"orbitPaths"`        // Draw the faint orbit path circles
+	MinClickInterval  int         `json:"minClickInterval"`  // Milliseconds between counted orb clicks, 0 means off
+	CompressSaves     bool        `json:"compressSaves"`     // Write saves as gzipped JSON instead of readable JSON
+	TabCatchUp        bool        `json:"tabCatchUp"`        // Credit production for time spent in a background browser tab
+	DoubleClickWindow int         `json:"doubleClickWindow"` // Milliseconds in which a second panel click buys max, 0 means off
+	PurchasePreview   bool        `json:"purchasePreview"`   // Show the production gain of the next level when hovering a generator
+	Muted             bool        `json:"muted"`             // Silence sound effects, toggled with M
+	Volume            int         `json:"volume"`            // Sound effect volume in percent
+	Fullscreen        bool        `json:"fullscreen"`        // Start in fullscreen, toggled with F11
+	PauseOnBlur       bool        `json:"pauseOnBlur"`       // Freeze the game while the window isn't focused
+	GeneratorLayout   string      `json:"generatorLayout"`   // Corner panels or the shop list, see generatorLayouts
+	NumberFormat      string      `json:"numberFormat"`      // How mana, costs and rates are written, see numberFormats
+	WindowWidth       int         `json:"windowWidth"`       // Last windowed size, 0 until a session has ended
+	WindowHeight      int         `json:"windowHeight"`
+}
+
+// Selectable FPS caps in the order the options menu cycles through them
+var fpsCaps = []int{30, 60, 120, 0}
+
+// Window title contents; "Off" keeps the static title
+var titleModes = []string{"Off", "Mana", "Mana/sec", "Both"}
+
+// Selectable window title update intervals in seconds
+var titleIntervals = []int{1, 5, 15}
+
+// Selectable orbit glow intensities
+var glowIntensities = []int{0, 50, 100, 150, 200, 255}
+
+// Circle rendering qualities, from cheapest to smoothest
+var circleQualities = []string{"Low", "Medium", "High"}
+
+// Selectable double-click windows in milliseconds, 0 means off
+var doubleClickWindows = []int{0, 250, 400, 600}
+
+// Selectable per-action purchase caps, 0 means unlimited
+var maxBuyPerActions = []int{10, 25, 100, 0}
+
+func defaultSettings() Settings {
+	return Settings{
+		FPSCap:            60,
+		Theme:             themes[0].name,
+		TitleMode:         "Off",
+		TitleInterval:     5,
+		Sparklines:        true,
+		GlowIntensity:     100,
+		ManaRounding:      "Decimals",
+		NumberFormat:      "Suffixed",
+		BuyRepeatRate:     10,
+		MaxBuyPerAction:   25,
+		BaselineTrickle:   true,
+		CircleQuality:     "Medium",
+		OrbitPaths:        true,
+		TabCatchUp:        true,
+		DoubleClickWindow: 400,
+		PurchasePreview:   true,
+		Volume:            75,
+		GeneratorLayout:   generatorLayouts[0],
+	}
+}
+
+// loadSettings reads settings from path, keeping defaults for anything missing
+func loadSettings(path string) (Settings, error) {
+	s := defaultSettings()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return defaultSettings(), err
+	}
+	return s, nil
+}
+
+func (s Settings) save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// Load settings from settingsPath, falling back to defaults
+func (g *Game) loadSettings() {
+	s, err := loadSettings(g.settingsPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("failed to load settings: %v", err)
+	}
+	g.settings = s
+}
+
+// Persist the current settings, logging instead of failing the game
+func (g *Game) saveSettings() {
+	if err := g.settings.save(g.settingsPath); err != nil {
+		log.Printf("failed to save settings: %v", err)
+	}
+}
+
+// Segments used for custom arcs; Medium matches the original fixed 32
+func (g *Game) arcSegments() int {
+	switch g.settings.CircleQuality {
+	case "Low":
+		return 16
+	case "High":
+		return 64
+	}
+	return 32
+}
+
+// Whether circles are drawn with antialiasing, only worth it at High quality
+func (g *Game) antialias() bool {
+	return g.settings.CircleQuality == "High"
+}
+
+// FPS cap with the tick rate actually reached in parentheses
+func (g *Game) fpsCapStatus() string {
+	if g.settings.FPSCap == 0 {
+		return fmt.Sprintf("Uncapped (%.0f)", ebiten.ActualTPS())
+	}
+	return fmt.Sprintf("%d (%.0f)", g.settings.FPSCap, ebiten.ActualTPS())
+}
+
+// Apply settings that are owned by Ebiten rather than the game state
+func (g *Game) applySettings() {
+	if g.settings.FPSCap > 0 {
+		ebiten.SetVsyncEnabled(true)
+		ebiten.SetTPS(g.settings.FPSCap)
+	} else {
+		// Uncapped: render as fast as possible and tick once per frame
+		ebiten.SetVsyncEnabled(false)
+		ebiten.SetTPS(ebiten.SyncWithFPS)
+	}
+	ebiten.SetFullscreen(g.settings.Fullscreen)
+	// Not running Update at all while unfocused also saves the CPU
+	ebiten.SetRunnableOnUnfocused(!g.settings.PauseOnBlur)
+}
+
+// Smallest window size restored from settings, anything below falls back to the default
+const (
+	minWindowWidth  = 640
+	minWindowHeight = 360
+)
+
+// Window size to open with: the last one used, or the default on first launch
+func (s Settings) windowSize() (int, int) {
+	if s.WindowWidth < minWindowWidth || s.WindowHeight < minWindowHeight {
+		return screenWidth, screenHeight
+	}
+	return s.WindowWidth, s.WindowHeight
+}
+
+// Use the given window size, keeping the saved one when it's too small.
+// A missing width or height is taken from the saved size.
+func (s *Settings) setWindowSize(w, h int) error {
+	savedW, savedH := s.windowSize()
+	if w == 0 {
+		w = savedW
+	}
+	if h == 0 {
+		h = savedH
+	}
+	if w < minWindowWidth || h < minWindowHeight {
+		return fmt.Errorf("window size %dx%d is below the minimum %dx%d", w, h, minWindowWidth, minWindowHeight)
+	}
+	s.WindowWidth, s.WindowHeight = w, h
+	return nil
+}
+
+// Remember the windowed size so the next launch opens the same window.
+// Fullscreen and browsers have no window size of their own to keep.
+func (g *Game) trackWindowSize() {
+	if ebiten.IsFullscreen() {
+		return
+	}
+	if w, h := ebiten.WindowSize(); w > 0 && h > 0 {
+		g.settings.WindowWidth, g.settings.WindowHeight = w, h
+	}
+}
+
+// Toggle fullscreen with F11 and remember it for the next launch
+func (g *Game) handleFullscreenKey() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		g.settings.Fullscreen = !ebiten.IsFullscreen()
+		ebiten.SetFullscreen(g.settings.Fullscreen)
+		g.saveSettings()
+	}
+}
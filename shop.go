@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Generator layouts: a panel in each corner, or a scrollable shop list that fits
+// any number of generators
+var generatorLayouts = []string{"Corners", "List"}
+
+const cornerPanels = 4 // Generators the corner layout has room for
+
+// Shop list layout (right side, below the prestige button)
+const (
+	shopWidth      = 520
+	shopMargin     = 30 // Distance of the list from the right edge
+	shopTop        = 120
+	shopBottom     = 80 // Distance of the list's bottom from the bottom edge
+	shopRowHeight  = 72
+	shopPadding    = 10
+	shopScrollStep = 40 // Pixels scrolled per wheel notch
+	shopBuyW       = 100
+	shopBuyH       = 28
+)
+
+// Whether generators are shown in the shop list instead of the corner panels.
+// There are only four corners, so more generators always use the list.
+func (g *Game) shopListShown() bool {
+	return g.settings.GeneratorLayout == "List" || g.shownGenerators() > cornerPanels
+}
+
+// Visible area of the shop list
+func (g *Game) shopRect() (x, y, w, h int) {
+	return g.width - shopWidth - shopMargin, shopTop, shopWidth, max(shopRowHeight, g.height-shopTop-shopBottom)
+}
+
+func (g *Game) overShop(x, y int) bool {
+	if !g.shopListShown() {
+		return false
+	}
+	sx, sy, sw, sh := g.shopRect()
+	return x >= sx && x <= sx+sw && y >= sy && y < sy+sh
+}
+
+// Row of generator i after scrolling; it may lie partly or fully outside the list
+func (g *Game) shopRowRect(i int) (x, y, w, h int) {
+	sx, sy, sw, _ := g.shopRect()
+	return sx, sy + i*shopRowHeight - int(g.shopScroll), sw, shopRowHeight
+}
+
+// Index of the generator whose row is at x, y, -1 if there is none
+func (g *Game) shopRowAt(x, y int) int {
+	if !g.overShop(x, y) {
+		return -1
+	}
+	_, sy, _, _ := g.shopRect()
+	i := (y - sy + int(g.shopScroll)) / shopRowHeight
+	if i >= g.shownGenerators() {
+		return -1
+	}
+	return i
+}
+
+// Whether the whole row of generator i is inside the list, so its buttons can be used
+func (g *Game) shopRowVisible(i int) bool {
+	_, sy, _, sh := g.shopRect()
+	_, ry, _, rh := g.shopRowRect(i)
+	return ry >= sy && ry+rh <= sy+sh
+}
+
+func (g *Game) maxShopScroll() float64 {
+	_, _, _, sh := g.shopRect()
+	return max(0, float64(g.shownGenerators()*shopRowHeight-sh))
+}
+
+// Index of the generator whose buy button is at x, y, -1 if there is none
+func (g *Game) shopBuyButtonAt(x, y int) int {
+	i := g.shopRowAt(x, y)
+	if i < 0 {
+		return -1
+	}
+	rx, ry, rw, _ := g.shopRowRect(i)
+	bx, by := rx+rw-shopBuyW-shopPadding, ry+6
+	if x >= bx && x <= bx+shopBuyW && y >= by && y <= by+shopBuyH {
+		return i
+	}
+	return -1
+}
+
+// Scroll the list with the mouse wheel while the cursor is over it, except over
+// a buy button where the wheel picks the quantity
+func (g *Game) updateShopScroll() {
+	x, y := ebiten.CursorPosition()
+	if _, dy := ebiten.Wheel(); dy != 0 && g.overShop(x, y) && g.shopBuyButtonAt(x, y) < 0 {
+		g.shopScroll -= dy * shopScrollStep
+	}
+	g.shopScroll = max(0, min(g.maxShopScroll(), g.shopScroll))
+}
+
+// Draw every generator as a row of the shop list, clipped to the list's area
+func (g *Game) drawShop(screen *ebiten.Image) {
+	theme := g.theme()
+	sx, sy, sw, sh := g.shopRect()
+	vector.DrawFilledRect(screen, float32(sx), float32(sy), float32(sw), float32(sh), color.RGBA{30, 30, 60, 220}, false)
+	vector.StrokeRect(screen, float32(sx), float32(sy), float32(sw), float32(sh), 1, color.RGBA{150, 150, 220, 255}, false)
+
+	list := screen.SubImage(image.Rect(sx, sy, sx+sw, sy+sh)).(*ebiten.Image)
+	cursorX, cursorY := ebiten.CursorPosition()
+	hovered := g.shopRowAt(cursorX, cursorY)
+	nameFace := g.face(20)
+	detailFace := g.face(16)
+
+	for i, generator := range g.generators[:g.shownGenerators()] {
+		rx, ry, rw, rh := g.shopRowRect(i)
+		if ry+rh < sy || ry > sy+sh {
+			continue
+		}
+		maxed := generator.level >= maxGeneratorLevel
+		if i == hovered && !g.optionsOpen && !generator.retired && !maxed {
+			vector.DrawFilledRect(list, float32(rx), float32(ry), float32(rw), float32(rh), color.RGBA{255, 255, 255, 20}, false)
+		}
+		vector.StrokeLine(list, float32(rx), float32(ry+rh), float32(rx+rw), float32(ry+rh), 1, color.RGBA{70, 70, 110, 255}, false)
+
+		// Maxed generators can't be bought anymore, gray out the whole row
+		rowColor := func(c color.RGBA) color.RGBA {
+			if maxed {
+				return color.RGBA{120, 120, 120, 255}
+			}
+			return c
+		}
+
+		buyLabel, cost := g.clickPurchase(i)
+		costColor := theme.unaffordable
+		if g.canAfford(cost) {
+			costColor = theme.affordable
+		}
+		name := fmt.Sprintf("%s: Lv%d", generator.name, generator.level)
+		detail := fmt.Sprintf("Cost %s  x%.2f  Can buy: %d", g.format(cost), generator.manaMultiplier, g.maxAffordable(i))
+		switch {
+		case generator.retired:
+			name = fmt.Sprintf("%s: Retired", generator.name)
+			detail = "Earned a retirement token"
+		case maxed:
+			detail = "Maxed - right-click to retire"
+		}
+
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(rx+shopPadding), float64(ry+8))
+		op.ColorScale.ScaleWithColor(rowColor(theme.text))
+		text.Draw(list, name, nameFace, op)
+
+		op = &text.DrawOptions{}
+		op.GeoM.Translate(float64(rx+shopPadding), float64(ry+42))
+		op.ColorScale.ScaleWithColor(rowColor(costColor))
+		text.Draw(list, detail, detailFace, op)
+
+		// Clicking anywhere on the row buys, the button just says how much
+		if generator.retired || maxed {
+			continue
+		}
+		bx, by := rx+rw-shopBuyW-shopPadding, ry+6
+		fill := color.RGBA{60, 60, 60, 255}
+		if g.canAfford(cost) {
+			fill = color.RGBA{40, 90, 60, 255}
+		}
+		vector.DrawFilledRect(list, float32(bx), float32(by), shopBuyW, shopBuyH, fill, false)
+		op = &text.DrawOptions{}
+		op.GeoM.Translate(float64(bx+10), float64(by+5))
+		op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+		text.Draw(list, "Buy "+buyLabel, detailFace, op)
+	}
+
+	// Scrollbar once the rows no longer fit
+	if maxScroll := g.maxShopScroll(); maxScroll > 0 {
+		total := float64(g.shownGenerators() * shopRowHeight)
+		barH := float64(sh) * float64(sh) / total
+		barY := float64(sy) + (float64(sh)-barH)*g.shopScroll/maxScroll
+		vector.DrawFilledRect(screen, float32(sx+sw-4), float32(barY), 3, float32(barH), color.RGBA{150, 150, 220, 200}, false)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	saveSlots      = 3           // Number of independent profiles
+	activeSlotPath = "slot.json" // Remembers the last used slot
+
+	resetConfirmWindow = 3.0 // Seconds a first click on Reset game stays armed
+)
+
+// Save and settings files of a slot. Slot 1 keeps the original file names so
+// existing saves carry over.
+func slotPaths(slot int) (savePath, settingsPath string) {
+	if slot <= 1 {
+		return defaultSavePath, defaultSettingsPath
+	}
+	suffix := fmt.Sprintf("-slot%d", slot)
+	return addPathSuffix(defaultSavePath, suffix), addPathSuffix(defaultSettingsPath, suffix)
+}
+
+// Insert suffix before the extension, e.g. "save.json" becomes "save-slot2.json"
+func addPathSuffix(path, suffix string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + suffix + ext
+}
+
+// Slot used last time, defaulting to the first one
+func loadActiveSlot() int {
+	b, err := os.ReadFile(activeSlotPath)
+	if err != nil {
+		return 1
+	}
+	var data struct {
+		Slot int `json:"slot"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil || data.Slot < 1 || data.Slot > saveSlots {
+		return 1
+	}
+	return data.Slot
+}
+
+func saveActiveSlot(slot int) error {
+	b, err := json.Marshal(struct {
+		Slot int `json:"slot"`
+	}{slot})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(activeSlotPath, b, 0o644)
+}
+
+// Save the current slot and continue with another one
+func (g *Game) switchSlot(slot int) {
+	if err := g.SaveGame(g.savePath); err != nil {
+		log.Printf("failed to save: %v", err)
+	}
+	g.loadSlot(slot)
+}
+
+// Replace the game state with the contents of slot
+func (g *Game) loadSlot(slot int) {
+	g.replaceWith(newGameInSlot(slot, WithRand(g.rng)))
+	g.logEvent("switched to slot %d", slot)
+
+	if err := saveActiveSlot(slot); err != nil {
+		log.Printf("failed to remember save slot: %v", err)
+	}
+}
+
+// Swap in the state of fresh, keeping what belongs to the session rather than the save
+func (g *Game) replaceWith(fresh *Game) {
+	fresh.seed = g.seed
+	fresh.events = g.events
+	fresh.optionsOpen = g.optionsOpen
+	fresh.debug = g.debug
+	fresh.timeScale = g.timeScale
+	fresh.gifEnabled = g.gifEnabled
+	fresh.status = g.status
+	fresh.orbitPanX, fresh.orbitPanY, fresh.orbitZoom = g.orbitPanX, g.orbitPanY, g.orbitZoom
+	*g = *fresh
+	g.applySettings()
+}
+
+// HardReset deletes the active save and starts over from the defaults, keeping
+// settings. A daily challenge restarts on its own seed.
+func (g *Game) HardReset() error {
+	if err := os.Remove(g.savePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	opt := WithRand(g.rng)
+	if g.dailyDate != "" {
+		opt = WithSeed(g.seed)
+	}
+	fresh := newGameWithPaths(g.slot, g.savePath, g.settingsPath, opt)
+	fresh.dailyDate = g.dailyDate
+	g.replaceWith(fresh)
+	g.logEvent("hard reset")
+	return nil
+}
+
+// Handle the reset row in the options menu; the first click arms it for
+// resetConfirmWindow seconds
+func (g *Game) confirmReset() {
+	if !g.confirmingReset {
+		g.confirmingReset = true
+		g.resetConfirmTimer = resetConfirmWindow
+		return
+	}
+	g.confirmingReset = false
+	if err := g.HardReset(); err != nil {
+		log.Printf("failed to reset: %v", err)
+	}
+}
+
+// Disarm the reset row when the confirming click doesn't come in time
+func (g *Game) updateResetConfirm(dt float64) {
+	if !g.confirmingReset {
+		return
+	}
+	g.resetConfirmTimer -= dt
+	if g.resetConfirmTimer <= 0 {
+		g.confirmingReset = false
+	}
+}
+
+// Delete the progress and settings of the active slot and start it over
+func (g *Game) deleteActiveSlot() error {
+	for _, path := range []string{g.savePath, g.settingsPath} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	g.loadSlot(g.slot)
+	return nil
+}
+
+// Handle the delete row in the options menu; the first click only arms it
+func (g *Game) confirmDeleteSlot() {
+	if !g.confirmingSlotDelete {
+		g.confirmingSlotDelete = true
+		return
+	}
+	g.confirmingSlotDelete = false
+	if err := g.deleteActiveSlot(); err != nil {
+		log.Printf("failed to delete save slot: %v", err)
+	}
+}
+
+// All slot numbers in menu order
+func slotNumbers() []int {
+	slots := make([]int, saveSlots)
+	for i := range slots {
+		slots[i] = i + 1
+	}
+	return slots
+}
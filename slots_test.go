@@ -0,0 +1,48 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSlotPathsDistinct(t *testing.T) {
+	seen := map[string]int{}
+	for _, slot := range slotNumbers() {
+		savePath, settingsPath := slotPaths(slot)
+		for _, path := range []string{savePath, settingsPath} {
+			if other, ok := seen[path]; ok {
+				t.Errorf("slots %d and %d share %s", other, slot, path)
+			}
+			seen[path] = slot
+		}
+	}
+}
+
+func TestSlotsDontClobber(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	g := newGameInSlot(1, WithoutAudio())
+	g.setMana(111)
+	g.switchSlot(2)
+	if g.slot != 2 || g.manaValue() != 0 {
+		t.Fatalf("slot %d starts with %v mana, want a fresh slot 2", g.slot, g.manaValue())
+	}
+	g.setMana(222)
+	g.switchSlot(1)
+	if math.Abs(g.manaValue()-111) > 1e-9 {
+		t.Errorf("slot 1 has %v mana after switching back, want 111", g.manaValue())
+	}
+
+	// Deleting a slot leaves the others alone
+	g.switchSlot(2)
+	if err := g.deleteActiveSlot(); err != nil {
+		t.Fatal(err)
+	}
+	if g.manaValue() != 0 {
+		t.Errorf("deleted slot 2 still has %v mana", g.manaValue())
+	}
+	g.switchSlot(1)
+	if math.Abs(g.manaValue()-111) > 1e-9 {
+		t.Errorf("slot 1 has %v mana after deleting slot 2, want 111", g.manaValue())
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import "math"
+
+// softCap leaves v alone up to threshold and grows only logarithmically beyond
+// it, so the result keeps rising but ever more slowly. The curve is continuous
+// with slope 1 at the threshold. A threshold of 0 or less disables the cap.
+func softCap(v, threshold float64) float64 {
+	if threshold <= 0 || v <= threshold {
+		return v
+	}
+	return threshold * (1 + math.Log(v/threshold))
+}
+
+// softCap for a BigNumber, which may be past float64's range. The capped result
+// always fits a float64 again.
+func softCapBig(v BigNumber, threshold float64) BigNumber {
+	if threshold <= 0 || v.Cmp(NewBigNumber(threshold)) <= 0 {
+		return v
+	}
+	return NewBigNumber(threshold * (1 + v.Log() - math.Log(threshold)))
+}
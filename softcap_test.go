@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSoftCap(t *testing.T) {
+	tests := []struct {
+		name         string
+		v, threshold float64
+		want         float64
+	}{
+		{"below the threshold", 500, 1000, 500},
+		{"at the threshold", 1000, 1000, 1000},
+		{"above the threshold", 1000 * math.E, 1000, 2000},
+		{"far above the threshold", 1000 * math.Exp(10), 1000, 11000},
+		{"disabled", 1e12, 0, 1e12},
+		{"negative threshold disables", 1e12, -5, 1e12},
+	}
+	for _, tt := range tests {
+		if got := softCap(tt.v, tt.threshold); math.Abs(got-tt.want) > tt.want*1e-12 {
+			t.Errorf("%s: softCap(%v, %v) = %v, want %v", tt.name, tt.v, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestSoftCapContinuous(t *testing.T) {
+	// Just past the threshold the curve still rises with slope 1
+	const threshold, eps = 1000.0, 1e-6
+	if got := softCap(threshold+eps, threshold) - threshold; math.Abs(got-eps) > eps*1e-3 {
+		t.Errorf("softCap rises by %v just past the threshold, want %v", got, eps)
+	}
+	// Beyond it the result keeps rising, ever more slowly
+	prev, prevStep := softCap(threshold, threshold), math.Inf(1)
+	for v := 2 * threshold; v <= 20*threshold; v += threshold {
+		got := softCap(v, threshold)
+		if step := got - prev; step <= 0 || step >= prevStep {
+			t.Errorf("softCap(%v) = %v after %v", v, got, prev)
+		} else {
+			prevStep = step
+		}
+		prev = got
+	}
+}
+
+func TestSoftCapBigMatchesSoftCap(t *testing.T) {
+	for _, v := range []float64{10, 1000, 5000, 1e100} {
+		want := softCap(v, 1000)
+		if got := softCapBig(NewBigNumber(v), 1000).Float64(); math.Abs(got-want) > want*1e-12 {
+			t.Errorf("softCapBig(%v) = %v, want %v", v, got, want)
+		}
+	}
+}
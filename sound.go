@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"io"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const audioSampleRate = 44100
+
+var (
+	//go:embed sounds/click.wav
+	clickWAV []byte
+	//go:embed sounds/buy.wav
+	buyWAV []byte
+)
+
+// Selectable sound volumes in percent
+var volumes = []int{25, 50, 75, 100}
+
+// sounds holds the decoded sound effects. A nil *sounds plays nothing, which is
+// what games get when audio can't be set up, e.g. in headless runs.
+type sounds struct {
+	context *audio.Context
+	click   []byte
+	buy     []byte
+}
+
+// WithoutAudio creates the game without an audio context, e.g. in tests where no
+// audio device exists. Sounds are then silently skipped.
+func WithoutAudio() GameOption {
+	return func(g *Game) {
+		g.audioDisabled = true
+	}
+}
+
+// Decode the embedded sound effects, returning nil if audio is unavailable
+func newSounds() *sounds {
+	// Only one audio context may exist per process, and switching slots creates new games
+	context := audio.CurrentContext()
+	if context == nil {
+		context = audio.NewContext(audioSampleRate)
+	}
+
+	decode := func(b []byte) ([]byte, error) {
+		stream, err := wav.DecodeWithSampleRate(audioSampleRate, bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(stream)
+	}
+	click, err := decode(clickWAV)
+	if err != nil {
+		log.Printf("failed to load click sound: %v", err)
+		return nil
+	}
+	buy, err := decode(buyWAV)
+	if err != nil {
+		log.Printf("failed to load purchase sound: %v", err)
+		return nil
+	}
+	return &sounds{context: context, click: click, buy: buy}
+}
+
+// Play a decoded sound at the volume from the settings unless muted
+func (g *Game) playSound(pcm func(s *sounds) []byte) {
+	if g.sounds == nil || g.settings.Muted {
+		return
+	}
+	player := g.sounds.context.NewPlayerFromBytes(pcm(g.sounds))
+	player.SetVolume(float64(g.settings.Volume) / 100)
+	player.Play()
+}
+
+func (g *Game) playClickSound() {
+	g.playSound(func(s *sounds) []byte { return s.click })
+}
+
+func (g *Game) playBuySound() {
+	g.playSound(func(s *sounds) []byte { return s.buy })
+}
+
+// Purchases that bought levels get their own sound, failed ones just click
+func (g *Game) playPurchaseSound(bought int) {
+	if bought > 0 {
+		g.playBuySound()
+	} else {
+		g.playClickSound()
+	}
+}
+
+// Toggle muting with M
+func (g *Game) handleMuteKey() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		g.settings.Muted = !g.settings.Muted
+		g.saveSettings()
+	}
+}
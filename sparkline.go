@@ -0,0 +1,75 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	historySize      = 60  // Samples kept per generator
+	historyInterval  = 1.0 // Seconds between samples
+	sparklineWidth   = 300
+	sparklineHeight  = 24
+	sparklineOffsetY = 135 // Below the generator panel text
+)
+
+// multiplierHistory is a fixed-size ring buffer of manaMultiplier samples
+type multiplierHistory struct {
+	samples [historySize]float64
+	count   int // Number of valid samples
+	next    int // Index the next sample is written to
+}
+
+func (h *multiplierHistory) add(v float64) {
+	h.samples[h.next] = v
+	h.next = (h.next + 1) % historySize
+	if h.count < historySize {
+		h.count++
+	}
+}
+
+// at returns the i-th sample, oldest first
+func (h *multiplierHistory) at(i int) float64 {
+	start := (h.next - h.count + historySize) % historySize
+	return h.samples[(start+i)%historySize]
+}
+
+// Record the current multiplier of every generator
+func (g *Game) sampleMultiplierHistory() {
+	for i := range g.generators {
+		g.multiplierHistories[i].add(g.generators[i].manaMultiplier)
+	}
+}
+
+// Draw the multiplier history of a generator as a line scaled to its own range
+func (g *Game) drawSparkline(screen *ebiten.Image, h *multiplierHistory, x, y float32, col color.RGBA) {
+	if h.count < 2 {
+		return
+	}
+
+	low, high := h.at(0), h.at(0)
+	for i := 1; i < h.count; i++ {
+		v := h.at(i)
+		low = min(low, v)
+		high = max(high, v)
+	}
+
+	// Flat history is drawn along the bottom edge
+	span := high - low
+	stepX := float32(sparklineWidth) / float32(historySize-1)
+	pointY := func(v float64) float32 {
+		if span == 0 {
+			return y + sparklineHeight
+		}
+		return y + sparklineHeight - float32((v-low)/span)*sparklineHeight
+	}
+
+	prevX, prevY := x, pointY(h.at(0))
+	for i := 1; i < h.count; i++ {
+		curX, curY := x+float32(i)*stepX, pointY(h.at(i))
+		vector.StrokeLine(screen, prevX, prevY, curX, curY, 2, col, false)
+		prevX, prevY = curX, curY
+	}
+}
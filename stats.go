@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Add earned mana, counting it towards the lifetime total. Anything above the
+// mana cap is wasted and values that can't be represented keep the old ones.
+func (g *Game) addMana(v float64) {
+	if math.IsNaN(v) {
+		return
+	}
+	g.earnMana(NewBigNumber(v))
+}
+
+// addMana for amounts that may be past float64's range. The lifetime total is a
+// statistic and stops at the largest float64.
+func (g *Game) earnMana(v BigNumber) {
+	if room := g.manaCap().Add(g.mana.Neg()); v.Cmp(room) > 0 {
+		v = room
+	}
+	if v.sign() <= 0 {
+		return
+	}
+	g.mana = g.mana.Add(v)
+	g.lifetimeMana = clampFinite(g.lifetimeMana+v.Float64(), g.lifetimeMana)
+}
+
+// Format seconds of play as hours and minutes
+func formatPlayTime(seconds float64) string {
+	minutes := int(seconds / 60)
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+// Draw lifetime statistics in the top left corner
+func (g *Game) drawStats(screen *ebiten.Image) {
+	stats := fmt.Sprintf("Lifetime: %s mana | Clicks: %d | Played: %s",
+		g.format(g.lifetimeMana), g.totalClicks, formatPlayTime(g.playTime))
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(20, 15)
+	op.ColorScale.ScaleWithColor(g.theme().textDim)
+	text.Draw(screen, stats, g.face(16), op)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// StatusSnapshot is the game state served by the status endpoint
+type StatusSnapshot struct {
+	Mana            float64           `json:"mana"`
+	ManaPerSec      float64           `json:"manaPerSec"`
+	TotalMultiplier float64           `json:"totalMultiplier"`
+	Generators      []GeneratorStatus `json:"generators"`
+}
+
+// GeneratorStatus is one generator in a StatusSnapshot
+type GeneratorStatus struct {
+	Name           string  `json:"name"`
+	Level          int     `json:"level"`
+	ManaMultiplier float64 `json:"manaMultiplier"`
+	Retired        bool    `json:"retired,omitempty"`
+}
+
+// statusServer serves the latest snapshot as JSON. Snapshots are taken on the game
+// loop and handed over under a mutex, so handlers never touch the Game itself.
+type statusServer struct {
+	mu       sync.Mutex
+	snapshot StatusSnapshot
+}
+
+// Listen on addr and serve the status endpoint in the background
+func startStatusServer(addr string) (*statusServer, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &statusServer{}
+	go func() {
+		if err := http.Serve(ln, s); err != nil {
+			log.Printf("status server stopped: %v", err)
+		}
+	}()
+	log.Printf("serving game status on http://%s/", ln.Addr())
+	return s, nil
+}
+
+func (s *statusServer) publish(snapshot StatusSnapshot) {
+	s.mu.Lock()
+	s.snapshot = snapshot
+	s.mu.Unlock()
+}
+
+func (s *statusServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	snapshot := s.snapshot
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		log.Printf("failed to write status: %v", err)
+	}
+}
+
+// Hand the current state to the status server, if one is running
+func (g *Game) publishStatus() {
+	if g.status == nil {
+		return
+	}
+	snapshot := StatusSnapshot{
+		Mana:            clampFinite(g.manaValue(), 0),
+		ManaPerSec:      g.totalMultiplier,
+		TotalMultiplier: g.totalMultiplier,
+		Generators:      make([]GeneratorStatus, len(g.generators)),
+	}
+	for i, generator := range g.generators {
+		snapshot.Generators[i] = GeneratorStatus{
+			Name:           generator.name,
+			Level:          generator.level,
+			ManaMultiplier: generator.manaMultiplier,
+			Retired:        generator.retired,
+		}
+	}
+	g.status.publish(snapshot)
+}
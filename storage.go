@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	baseManaCap      = 10000.0 // Mana the storage holds before any upgrade
+	storageCapFactor = 10.0    // Each storage upgrade multiplies the cap by this
+	storageCostShare = 0.5     // An upgrade costs this share of the current cap
+)
+
+// Storage upgrade button layout (right of the mana readout)
+const (
+	storageButtonGap = 20
+	storageButtonY   = 52
+	storageButtonW   = 220
+	storageButtonH   = 34
+)
+
+// Most mana that can be stored; production beyond it is wasted
+func (g *Game) manaCap() BigNumber {
+	return manaCapAt(g.storageLevel)
+}
+
+// Mana cap at a storage level, which grows past float64's range
+func manaCapAt(level int) BigNumber {
+	return NewBigNumber(baseManaCap).Mul(NewBigNumber(storageCapFactor).Pow(level))
+}
+
+// Storage level whose cap holds mana, so saves from before the cap lose nothing
+func storageLevelFor(mana BigNumber) int {
+	if mana.Cmp(manaCapAt(0)) <= 0 {
+		return 0
+	}
+	// Start from the logarithm instead of counting up, a save can hold mana with
+	// an exponent in the billions
+	level := int(math.Ceil((mana.Log() - math.Log(baseManaCap)) / math.Log(storageCapFactor)))
+	// Rounding in the logarithm can leave the level off either way
+	for level > 0 && manaCapAt(level-1).Cmp(mana) >= 0 {
+		level--
+	}
+	for manaCapAt(level).Cmp(mana) < 0 {
+		level++
+	}
+	return level
+}
+
+// Replace the current mana for debugging, growing the storage to hold it
+func (g *Game) setDebugMana(mana float64) error {
+	if !isFinite(mana) || mana < 0 {
+		return fmt.Errorf("invalid mana %v", mana)
+	}
+	g.setMana(mana)
+	g.storageLevel = max(g.storageLevel, storageLevelFor(g.mana))
+	return nil
+}
+
+func (g *Game) storageCost() BigNumber {
+	return g.manaCap().Mul(NewBigNumber(storageCostShare))
+}
+
+// Whether new mana is currently being wasted
+func (g *Game) atManaCap() bool {
+	return g.mana.Cmp(g.manaCap()) >= 0
+}
+
+// Multiply the mana cap
+func (g *Game) buyStorage() bool {
+	cost := g.storageCost()
+	if g.economyFrozen() || g.mana.Cmp(cost) < 0 {
+		return false
+	}
+	g.mana = g.mana.Add(cost.Neg())
+	g.storageLevel++
+	g.logEvent("expanded storage to %s", g.formatBig(g.manaCap()))
+	return true
+}
+
+// Mana readout with the cap, as shown at the top left
+func (g *Game) manaText() string {
+	s := fmt.Sprintf("Mana: %s / %s", g.formatMana(), g.formatBig(g.manaCap()))
+	if g.economyPaused {
+		s += " (economy paused)"
+	}
+	return s
+}
+
+func (g *Game) manaFace() *text.GoTextFace {
+	return g.face(32) // Large font size
+}
+
+// Storage button, following the mana readout wherever its text ends
+func (g *Game) storageRect() (x, y, w, h int) {
+	textW, _ := text.Measure(g.manaText(), g.manaFace(), 0)
+	return 20 + int(textW) + storageButtonGap, storageButtonY, storageButtonW, storageButtonH
+}
+
+// Handle a click on the storage button, reporting whether it was consumed
+func (g *Game) handleStorageClick(x, y int) bool {
+	bx, by, bw, bh := g.storageRect()
+	if x >= bx && x <= bx+bw && y >= by && y <= by+bh {
+		g.buyStorage()
+		return true
+	}
+	return false
+}
+
+func (g *Game) drawStorageButton(screen *ebiten.Image) {
+	x, y, w, h := g.storageRect()
+	cost := g.storageCost()
+	fill := color.RGBA{60, 60, 60, 255}
+	if g.mana.Cmp(cost) >= 0 {
+		fill = color.RGBA{40, 90, 60, 255}
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), fill, false)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x)+8, float64(y)+7)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, fmt.Sprintf("Storage x%.0f: %s", storageCapFactor, g.formatBig(cost)), g.face(16), op)
+}
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestManaCapAt(t *testing.T) {
+	tests := []struct {
+		level int
+		want  float64
+	}{
+		{0, baseManaCap},
+		{1, baseManaCap * storageCapFactor},
+		{3, baseManaCap * storageCapFactor * storageCapFactor * storageCapFactor},
+		{10, 1e14},
+	}
+	for _, tt := range tests {
+		if got := manaCapAt(tt.level); got.Cmp(NewBigNumber(tt.want)) != 0 {
+			t.Errorf("manaCapAt(%d) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestEarnManaStopsAtCap(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.storageLevel = 0
+	g.setMana(baseManaCap - 10)
+	g.addMana(100)
+	if g.mana.Cmp(g.manaCap()) != 0 || !g.atManaCap() {
+		t.Errorf("mana = %v past the cap %v", g.mana, g.manaCap())
+	}
+}
+
+func TestBuyStorage(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.storageLevel = 0
+	g.setMana(baseManaCap * storageCostShare * 0.99)
+	if g.buyStorage() {
+		t.Fatal("unaffordable storage upgrade was bought")
+	}
+	g.setMana(baseManaCap)
+	if !g.buyStorage() {
+		t.Fatal("affordable storage upgrade wasn't bought")
+	}
+	if g.storageLevel != 1 || g.manaValue() != baseManaCap*(1-storageCostShare) {
+		t.Errorf("storage level %d, mana %v after the upgrade", g.storageLevel, g.manaValue())
+	}
+	if g.manaCap().Cmp(manaCapAt(1)) != 0 {
+		t.Errorf("cap = %v after the upgrade, want %v", g.manaCap(), manaCapAt(1))
+	}
+}
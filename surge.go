@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const surgeRollInterval = 60.0 // Seconds between rolls for a mana surge
+
+// Mana surge banner layout (top center)
+const (
+	surgeBannerY    = 160
+	surgeBannerSize = 26
+	surgeBannerPad  = 10
+)
+
+// Roll for a mana surge once a minute and count down a running one. Rolls use
+// the game's seeded RNG, so a daily challenge gets the same surges for everyone.
+func (g *Game) updateSurge(dt float64) {
+	if g.surgeRemaining > 0 {
+		g.surgeRemaining = max(0, g.surgeRemaining-dt)
+		if g.surgeRemaining == 0 {
+			// Drop back to the normal rate right away
+			g.calculateManaPerSec()
+		}
+	}
+
+	g.surgeRollTimer += dt
+	if g.surgeRollTimer < surgeRollInterval {
+		return
+	}
+	g.surgeRollTimer -= surgeRollInterval
+	if g.surgeRemaining == 0 && g.rng.Float64() < g.config.Surge.Chance {
+		g.surgeRemaining = g.config.Surge.Duration
+		g.logEvent("mana surge started")
+		g.calculateManaPerSec()
+	}
+}
+
+// Global production factor from a running mana surge
+func (g *Game) surgeMultiplier() float64 {
+	if g.surgeRemaining > 0 {
+		return g.config.Surge.Multiplier
+	}
+	return 1
+}
+
+// Announce a running surge with a banner counting down its seconds
+func (g *Game) drawSurgeBanner(screen *ebiten.Image) {
+	if g.surgeRemaining <= 0 {
+		return
+	}
+	label := fmt.Sprintf("MANA SURGE! x%.0f production for %.0fs", g.config.Surge.Multiplier, g.surgeRemaining)
+	face := g.face(surgeBannerSize)
+	w, h := text.Measure(label, face, 0)
+	x := float64(g.width)/2 - w/2
+	vector.DrawFilledRect(screen, float32(x-surgeBannerPad), surgeBannerY-surgeBannerPad, float32(w+2*surgeBannerPad), float32(h+2*surgeBannerPad), color.RGBA{90, 30, 140, 200}, false)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(x, surgeBannerY)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 230, 120, 255})
+	text.Draw(screen, label, face, op)
+}
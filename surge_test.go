@@ -0,0 +1,92 @@
+package main
+
+import "testing"
+
+// A game without generators whose next surge rolls come from rolls
+func newSurgeTestGame(t *testing.T, rolls ...float64) *Game {
+	t.Helper()
+	g := newTestGame(t, WithRand(scriptedRand(rolls...)))
+	for i := range g.generators {
+		g.generators[i].level = 0
+	}
+	g.storageLevel = 20
+	g.config.Surge = SurgeConfig{Chance: 0.25, Multiplier: 3, Duration: 10}
+	g.calculateManaPerSec()
+	return g
+}
+
+func TestSurgeRoll(t *testing.T) {
+	tests := []struct {
+		name    string
+		roll    float64
+		elapsed float64
+		want    bool
+	}{
+		{"lucky roll after a minute", 0.125, surgeRollInterval, true},
+		{"unlucky roll after a minute", 0.5, surgeRollInterval, false},
+		{"no roll within the first minute", 0, surgeRollInterval - 1, false},
+	}
+	for _, tt := range tests {
+		g := newSurgeTestGame(t, tt.roll)
+		rate := g.totalMultiplier
+		g.updateSurge(tt.elapsed)
+		if got := g.surgeRemaining > 0; got != tt.want {
+			t.Errorf("%s: surge started = %v, want %v", tt.name, got, tt.want)
+		}
+		want := rate
+		if tt.want {
+			want = rate * 3
+		}
+		if g.totalMultiplier != want {
+			t.Errorf("%s: production %v, want %v", tt.name, g.totalMultiplier, want)
+		}
+	}
+}
+
+func TestSurgeExpires(t *testing.T) {
+	g := newSurgeTestGame(t, 0)
+	rate := g.totalMultiplier
+	g.updateSurge(surgeRollInterval)
+	if g.surgeRemaining != 10 || g.surgeMultiplier() != 3 {
+		t.Fatalf("surge remaining %v, multiplier %v", g.surgeRemaining, g.surgeMultiplier())
+	}
+
+	g.updateSurge(4)
+	if g.surgeRemaining != 6 {
+		t.Errorf("surge remaining = %v after 4s, want 6", g.surgeRemaining)
+	}
+	g.updateSurge(7)
+	if g.surgeRemaining != 0 || g.surgeMultiplier() != 1 || g.totalMultiplier != rate {
+		t.Errorf("after expiry: remaining %v, multiplier %v, production %v", g.surgeRemaining, g.surgeMultiplier(), g.totalMultiplier)
+	}
+}
+
+func TestSurgeDoesntStackWhileRunning(t *testing.T) {
+	g := newSurgeTestGame(t, 0)
+	g.config.Surge.Duration = surgeRollInterval * 2
+	g.updateSurge(surgeRollInterval)
+	g.updateSurge(surgeRollInterval)
+	if want := surgeRollInterval; g.surgeRemaining != want {
+		t.Errorf("surge remaining = %v after a second roll, want the first surge's %v", g.surgeRemaining, want)
+	}
+}
+
+func TestNoSurgeWhileFrozen(t *testing.T) {
+	g := newSurgeTestGame(t, 0)
+	g.economyPaused = true
+	for range int(2 * 60 * surgeRollInterval) {
+		g.step(1.0 / 60)
+	}
+	if g.surgeRemaining != 0 || g.surgeRollTimer != 0 {
+		t.Errorf("surge remaining %v, roll timer %v while frozen", g.surgeRemaining, g.surgeRollTimer)
+	}
+
+	// Once the economy resumes the next roll is a full minute away
+	g.economyPaused = false
+	for range int(60 * (surgeRollInterval + 1)) {
+		g.step(1.0 / 60)
+	}
+	if g.surgeRemaining == 0 {
+		t.Error("no surge a minute after resuming")
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+)
+
+// synergy is a SynergyConfig with its generators looked up
+type synergy struct {
+	source, target int
+	bonusPerLevel  float64
+}
+
+// Look up the generators of the configured synergies. Synergies naming a generator
+// that isn't configured are skipped, so renaming or removing generators in
+// config.json doesn't need the synergies to be edited too.
+func (c Config) synergies() []synergy {
+	index := func(name string) int {
+		return slices.IndexFunc(c.Generators, func(gc GeneratorConfig) bool { return gc.Name == name })
+	}
+	var resolved []synergy
+	for _, sc := range c.Synergies {
+		source, target := index(sc.Source), index(sc.Target)
+		if source < 0 || target < 0 {
+			log.Printf("config: skipping synergy %s -> %s, no such generator", sc.Source, sc.Target)
+			continue
+		}
+		resolved = append(resolved, synergy{source: source, target: target, bonusPerLevel: sc.BonusPerLevel})
+	}
+	return resolved
+}
+
+// Bonus the levels of other generators give generator i's multiplier, 1 for none.
+// Only active generators give or receive a synergy.
+func (g *Game) synergyMultiplier(i int) float64 {
+	if !g.generators[i].active() {
+		return 1
+	}
+	bonus := 0.0
+	for _, s := range g.synergies {
+		if s.target == i && g.generators[s.source].active() {
+			bonus += s.bonusPerLevel * float64(g.generators[s.source].level)
+		}
+	}
+	return 1 + bonus
+}
+
+// Combined synergy bonus of all generators, a factor of production
+func (g *Game) totalSynergyMultiplier() float64 {
+	total := 1.0
+	for i := range g.generators {
+		total *= g.synergyMultiplier(i)
+	}
+	return total
+}
+
+// Tooltip lines describing the synergies generator i gives and receives
+func (g *Game) synergyTooltip(i int) []string {
+	var lines []string
+	if m := g.synergyMultiplier(i); m > 1 {
+		lines = append(lines, fmt.Sprintf("Synergy bonus: x%.2f", m))
+	}
+	if !g.generators[i].active() {
+		return lines
+	}
+	for _, s := range g.synergies {
+		if s.source == i && g.generators[s.target].active() {
+			lines = append(lines, fmt.Sprintf("Boosts %s by %+.0f%%", g.generators[s.target].name,
+				s.bonusPerLevel*float64(g.generators[i].level)*100))
+		}
+	}
+	return lines
+}
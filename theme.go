@@ -0,0 +1,148 @@
+package main
+
+import (
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Theme holds the colors of the screen and its UI cues
+type Theme struct {
+	name         string
+	promoCode    string       // Code that unlocks the theme, empty if always available
+	background   color.RGBA   // Screen fill behind everything
+	text         color.RGBA   // Mana readout, multiplier line and panel titles
+	textDim      color.RGBA   // Secondary panel text
+	multiplier   color.RGBA   // Panel multiplier text and sparklines
+	affordable   color.RGBA   // Cost text when the next level can be bought
+	unaffordable color.RGBA   // Cost text when mana is short
+	indicators   []color.RGBA // Orbit indicator of each generator
+	orb          color.RGBA   // Mana orb and its glow
+}
+
+// Built-in themes, the first one is the default
+var themes = []Theme{
+	{
+		name:         "Default",
+		background:   color.RGBA{25, 25, 50, 255},
+		text:         color.RGBA{255, 255, 255, 255},
+		textDim:      color.RGBA{200, 200, 200, 255},
+		multiplier:   color.RGBA{100, 255, 100, 255},
+		affordable:   color.RGBA{120, 220, 120, 255},
+		unaffordable: color.RGBA{220, 100, 100, 255},
+		indicators: []color.RGBA{
+			{255, 100, 100, 255}, // Red
+			{255, 200, 100, 255}, // Orange
+			{100, 255, 100, 255}, // Green
+			{100, 200, 255, 255}, // Blue
+			{200, 120, 255, 255}, // Purple
+			{255, 120, 200, 255}, // Pink
+			{100, 255, 220, 255}, // Teal
+			{230, 230, 120, 255}, // Yellow
+		},
+		orb: color.RGBA{120, 140, 255, 255},
+	},
+	{
+		name:         "High contrast",
+		background:   color.RGBA{0, 0, 0, 255},
+		text:         color.RGBA{255, 255, 255, 255},
+		textDim:      color.RGBA{235, 235, 235, 255},
+		multiplier:   color.RGBA{0, 255, 0, 255},
+		affordable:   color.RGBA{0, 255, 0, 255},
+		unaffordable: color.RGBA{255, 40, 40, 255},
+		indicators: []color.RGBA{
+			{255, 60, 60, 255},
+			{255, 220, 0, 255},
+			{0, 255, 0, 255},
+			{0, 200, 255, 255},
+			{255, 0, 255, 255},
+			{255, 255, 255, 255},
+			{255, 140, 0, 255},
+			{160, 255, 160, 255},
+		},
+		orb: color.RGBA{0, 200, 255, 255},
+	},
+	{
+		// Okabe-Ito palette, distinguishable with the common color vision deficiencies
+		name:         "Colorblind safe",
+		background:   color.RGBA{25, 25, 50, 255},
+		text:         color.RGBA{255, 255, 255, 255},
+		textDim:      color.RGBA{200, 200, 200, 255},
+		multiplier:   color.RGBA{240, 228, 66, 255},
+		affordable:   color.RGBA{86, 180, 233, 255},
+		unaffordable: color.RGBA{230, 159, 0, 255},
+		indicators: []color.RGBA{
+			{213, 94, 0, 255},    // Vermillion
+			{86, 180, 233, 255},  // Sky blue
+			{240, 228, 66, 255},  // Yellow
+			{0, 158, 115, 255},   // Bluish green
+			{230, 159, 0, 255},   // Orange
+			{0, 114, 178, 255},   // Blue
+			{204, 121, 167, 255}, // Reddish purple
+			{255, 255, 255, 255}, // White
+		},
+		orb: color.RGBA{0, 114, 178, 255}, // Blue
+	},
+	{
+		name:         "Midnight",
+		promoCode:    "MIDNIGHT",
+		background:   color.RGBA{10, 10, 30, 255},
+		text:         color.RGBA{220, 225, 255, 255},
+		textDim:      color.RGBA{160, 165, 200, 255},
+		multiplier:   color.RGBA{150, 170, 255, 255},
+		affordable:   color.RGBA{150, 170, 255, 255},
+		unaffordable: color.RGBA{120, 90, 140, 255},
+		indicators: []color.RGBA{
+			{180, 120, 255, 255},
+			{120, 160, 255, 255},
+			{100, 220, 230, 255},
+			{230, 150, 255, 255},
+			{140, 200, 255, 255},
+			{200, 180, 255, 255},
+			{90, 150, 220, 255},
+			{255, 200, 240, 255},
+		},
+		orb: color.RGBA{150, 100, 255, 255},
+	},
+}
+
+// Indicator color of generator i, repeating the palette past its end
+func (t Theme) indicator(i int) color.RGBA {
+	return t.indicators[i%len(t.indicators)]
+}
+
+// Look up a theme by name, falling back to the default one
+func themeByName(name string) Theme {
+	for _, t := range themes {
+		if t.name == name {
+			return t
+		}
+	}
+	return themes[0]
+}
+
+// Names of the themes the player can pick, used to cycle through them
+func (g *Game) themeNames() []string {
+	var names []string
+	for _, t := range themes {
+		if t.promoCode == "" || slices.Contains(g.redeemedCodes, t.promoCode) {
+			names = append(names, t.name)
+		}
+	}
+	return names
+}
+
+// Currently active theme
+func (g *Game) theme() Theme {
+	return themeByName(g.settings.Theme)
+}
+
+// Cycle through the available themes with T
+func (g *Game) handleThemeKey() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		g.settings.Theme = nextInCycle(g.themeNames(), g.theme().name)
+		g.saveSettings()
+	}
+}
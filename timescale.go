@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Simulation speeds cycled by the debug fast forward key
+var timeScales = []float64{1, 10, 100, 1000}
+
+// Cycle the fast forward speed with F7 (debug only)
+func (g *Game) handleTimeScaleKey() {
+	if g.debug && inpututil.IsKeyJustPressed(ebiten.KeyF7) {
+		g.timeScale = nextInCycle(timeScales, g.timeScale)
+		g.logEvent("time scale: %vx", g.timeScale)
+	}
+}
+
+// Advance the simulation by dt seconds of real time. Fast forward runs several
+// ticks of the normal length rather than one long tick, so no rotation boundary
+// or cost step is skipped and the result matches playing for that long.
+func (g *Game) step(dt float64) {
+	// Recalculating the rate walks the whole production breakdown, so it's done
+	// once per tick rather than for each of up to a thousand fast forward ticks.
+	// Rotation gains within the tick count from the next one.
+	if !g.economyFrozen() {
+		g.calculateManaPerSec()
+	}
+	if g.timeScale <= 1 {
+		g.advance(dt)
+		return
+	}
+	ticks := int(math.Ceil(g.timeScale))
+	sub := dt * g.timeScale / float64(ticks)
+	for range ticks {
+		g.advance(sub)
+	}
+}
+
+func (g *Game) drawTimeScale(screen *ebiten.Image) {
+	if g.timeScale == 1 {
+		return
+	}
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(tokenShopX, 145)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 150, 150, 255})
+	text.Draw(screen, fmt.Sprintf("Fast forward %vx (F7)", g.timeScale), g.face(18), op)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// A game without owned generators, whose production rate stays constant
+func newIdleTestGame(t *testing.T) *Game {
+	t.Helper()
+	g := newTestGame(t)
+	g.config.Surge.Chance = 0
+	for i := range g.generators {
+		g.generators[i].level = 0
+	}
+	g.storageLevel = 20
+	g.setMana(0)
+	g.calculateManaPerSec()
+	if g.totalMultiplier <= 0 {
+		t.Fatalf("idle production rate is %v", g.totalMultiplier)
+	}
+	return g
+}
+
+func TestStep(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeScale float64
+		dt        float64
+		ticks     int
+		seconds   float64 // Simulated time the ticks add up to
+	}{
+		{"one second at 60 TPS", 1, 1.0 / 60, 60, 1},
+		{"a minute at 60 TPS", 1, 1.0 / 60, 3600, 60},
+		{"one long tick", 1, 5, 1, 5},
+		{"10x fast forward", 10, 1.0 / 60, 60, 10},
+		{"1000x fast forward", 1000, 1.0 / 60, 6, 100},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.timeScale = tt.timeScale
+		rate := g.totalMultiplier
+		for range tt.ticks {
+			g.step(tt.dt)
+		}
+		if want := rate * tt.seconds; math.Abs(g.manaValue()-want) > want*1e-9 {
+			t.Errorf("%s: mana = %v, want %v", tt.name, g.manaValue(), want)
+		}
+	}
+}
+
+func TestStepFrozenEconomy(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.economyPaused = true
+	for range 60 {
+		g.step(1.0 / 60)
+	}
+	if g.manaValue() != 0 {
+		t.Errorf("mana = %v while the economy is paused, want 0", g.manaValue())
+	}
+}
+
+func TestSoftPauseKeepsAnimations(t *testing.T) {
+	for _, mode := range []timerMode{timerModeIndependent, timerModeShared} {
+		g := newIdleTestGame(t)
+		g.timerMode = mode
+		g.generators[0].level = 10
+		g.setMana(1e6)
+		g.unlockAnimations[0] = unlockAnimationDuration
+		g.economyPaused = true
+		multiplier := g.generators[0].manaMultiplier
+		angles := slices.Clone(g.rotationAngles)
+		shared := g.sharedRotationAngle
+		for range 120 {
+			g.step(1.0 / 60)
+		}
+
+		if g.manaValue() != 1e6 || g.generators[0].manaMultiplier != multiplier {
+			t.Errorf("%v: mana %v, multiplier %v while soft paused", mode, g.manaValue(), g.generators[0].manaMultiplier)
+		}
+		if slices.Equal(angles, g.rotationAngles) && shared == g.sharedRotationAngle {
+			t.Errorf("%v: indicators stopped turning while soft paused", mode)
+		}
+		if g.unlockAnimations[0] == unlockAnimationDuration {
+			t.Errorf("%v: unlock animation stopped while soft paused", mode)
+		}
+		if g.buyBulk(0, 1) != 0 {
+			t.Errorf("%v: bought a level while soft paused", mode)
+		}
+	}
+}
+
+func BenchmarkStepFastForward(b *testing.B) {
+	g := newTestGame(b)
+	g.timeScale = 1000
+	b.ReportAllocs()
+	for b.Loop() {
+		g.step(1.0 / 60)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Enable toggle layout, above the upgrade button or left of the shop row's buy button
+const (
+	toggleW = 56
+	toggleH = 26
+)
+
+// Owned generators have a toggle, unless their shop row is scrolled out of view
+func (g *Game) toggleShown(i int) bool {
+	return g.generators[i].owned() && (!g.shopListShown() || g.shopRowVisible(i))
+}
+
+// Enable toggle of generator i
+func (g *Game) toggleRect(i int) (x, y, w, h int) {
+	if g.shopListShown() {
+		px, py, pw, _ := g.generatorRect(i)
+		return px + pw - shopBuyW - shopPadding - toggleW - 8, py + 6, toggleW, toggleH
+	}
+	ux, uy, _, _ := g.upgradeRect(i)
+	return ux, uy - toggleH - 6, toggleW, toggleH
+}
+
+// Stop or resume generator i. A disabled generator keeps its levels and
+// multiplier but doesn't rotate and counts as x1 in production.
+func (g *Game) toggleGenerator(i int) {
+	generator := &g.generators[i]
+	generator.enabled = !generator.enabled
+	if generator.enabled {
+		g.logEvent("enabled %s", generator.name)
+	} else {
+		g.logEvent("disabled %s", generator.name)
+	}
+	g.calculateManaPerSec()
+}
+
+// Handle a click on an enable toggle, reporting whether it was consumed
+func (g *Game) handleToggleClicks(x, y int) bool {
+	for i := range g.generators {
+		if !g.toggleShown(i) {
+			continue
+		}
+		bx, by, bw, bh := g.toggleRect(i)
+		if x >= bx && x <= bx+bw && y >= by && y <= by+bh {
+			g.toggleGenerator(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) drawToggles(screen *ebiten.Image) {
+	for i, generator := range g.generators {
+		if !g.toggleShown(i) {
+			continue
+		}
+		x, y, w, h := g.toggleRect(i)
+		fill, label := color.RGBA{40, 90, 60, 255}, "On"
+		if !generator.enabled {
+			fill, label = color.RGBA{90, 40, 40, 255}, "Off"
+		}
+		vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), fill, false)
+
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(x)+float64(w)/2, float64(y)+float64(h)/2)
+		op.PrimaryAlign = text.AlignCenter
+		op.SecondaryAlign = text.AlignCenter
+		op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+		text.Draw(screen, label, g.face(16), op)
+	}
+}
+
+// Faded version of c for the orbit of a disabled generator
+func dimmed(c color.RGBA) color.RGBA {
+	return color.RGBA{c.R / 3, c.G / 3, c.B / 3, c.A}
+}
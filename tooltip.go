@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const tooltipCursorOffset = 16 // Gap between the cursor and the tooltip's corner
+
+// Production generator i accounts for: how much lower the rate would be with its
+// multiplier at 1
+func (g *Game) generatorContribution(i int) float64 {
+	generator := g.generators[i]
+	if !generator.active() || generator.manaMultiplier <= 0 {
+		return 0
+	}
+	floor := 0.0
+	if g.settings.BaselineTrickle {
+		floor = g.baselineProduction
+	}
+	raw, threshold := g.rawProductionMultiplier(), g.config.SoftCap
+	return max(softCap(raw, threshold), floor) - max(softCap(raw/generator.manaMultiplier, threshold), floor)
+}
+
+// Lines of the tooltip for generator i
+func (g *Game) generatorTooltip(i int) []string {
+	generator := g.generators[i]
+	lines := []string{generator.name, generator.description}
+	if generator.active() {
+		lines = append(lines,
+			fmt.Sprintf("Contributes %s/sec (x%.2f)", g.format(g.generatorContribution(i)), generator.manaMultiplier),
+			fmt.Sprintf("Each rotation: +%s/sec", g.format(g.manaPerRotation(i))))
+	}
+	lines = append(lines, g.synergyTooltip(i)...)
+	if !generator.retired && generator.level < maxGeneratorLevel {
+		rateDelta, growthDelta := g.previewPurchase(i)
+		lines = append(lines, fmt.Sprintf("Next level: %+.2f/sec now, %+.4f/sec per second", rateDelta, growthDelta))
+	}
+	return lines
+}
+
+// Draw the tooltip of the hovered generator panel next to the cursor, kept on screen
+func (g *Game) drawGeneratorTooltip(screen *ebiten.Image) {
+	cursorX, cursorY := ebiten.CursorPosition()
+	i := g.generatorAt(cursorX, cursorY)
+	if i < 0 {
+		return
+	}
+	lines := g.generatorTooltip(i)
+
+	face := g.face(breakdownLineSize)
+	width := 0.0
+	for _, line := range lines {
+		w, _ := text.Measure(line, face, 0)
+		width = max(width, w)
+	}
+	boxW := float32(width) + breakdownPadding*2
+	boxH := float32(len(lines)*breakdownLineH) + breakdownPadding*2
+
+	x := min(float32(cursorX+tooltipCursorOffset), float32(g.width)-boxW)
+	y := min(float32(cursorY+tooltipCursorOffset), float32(g.height)-boxH)
+	x, y = max(0, x), max(0, y)
+	vector.DrawFilledRect(screen, x, y, boxW, boxH, color.RGBA{20, 20, 40, 235}, false)
+	vector.StrokeRect(screen, x, y, boxW, boxH, 1, color.RGBA{150, 150, 220, 255}, false)
+
+	for i, line := range lines {
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(x)+breakdownPadding, float64(y)+breakdownPadding+float64(i*breakdownLineH))
+		op.ColorScale.ScaleWithColor(color.RGBA{230, 230, 230, 255})
+		text.Draw(screen, line, face, op)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Handle a left click or tap at x, y, reporting whether it hit anything. Mouse
+// and touch input share this so both reach the same buttons, panels and orb.
+func (g *Game) handleTapAt(x, y int) bool {
+	if g.optionsOpen {
+		g.handleOptionsClick(x, y)
+		return true
+	}
+	if g.prestigeConfirmOpen {
+		g.handlePrestigeConfirmClick(x, y)
+		return true
+	}
+	if g.handleTokenClicks(x, y) || g.handleStorageClick(x, y) || g.handleUpgradeClicks(x, y) || g.handleToggleClicks(x, y) || g.handlePrestigeClick(x, y) || g.handleBuyToggleClick(x, y) || g.handleCritClicks(x, y) || g.handleTrickleClick(x, y) || g.handleViewResetClick(x, y) || g.handleOverclockClick(x, y) {
+		return true
+	}
+	if g.generatorAt(x, y) >= 0 {
+		g.handleGeneratorClicks(x, y)
+		return true
+	}
+	if g.isMouseOverOrb(float64(x), float64(y)) {
+		g.clickOrb()
+		return true
+	}
+	return false
+}
+
+// Handle new taps on a touch screen. Only the first new touch of a tick counts,
+// so a palm or two fingers landing together can't buy twice.
+func (g *Game) handleTouches() {
+	g.touchIDs = inpututil.AppendJustPressedTouchIDs(g.touchIDs[:0])
+	if len(g.touchIDs) == 0 {
+		return
+	}
+	x, y := ebiten.TouchPosition(g.touchIDs[0])
+	g.handleTapAt(x, y)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	baseTrickle        = 1.0  // Baseline mana/sec before any upgrades
+	trickleStep        = 1.0  // Baseline added per upgrade
+	trickleBaseCost    = 25.0 // Cost of the first upgrade
+	trickleCostScaling = 3.0  // Cost growth per upgrade
+)
+
+// Trickle upgrade button layout (bottom center, above the boost bar)
+const (
+	trickleButtonWidth  = 300
+	trickleButtonHeight = 36
+	trickleButtonBottom = 150 // Distance of the button's top from the bottom edge
+)
+
+// Baseline production after level upgrades
+func trickleBaseline(level int) float64 {
+	return baseTrickle + trickleStep*float64(level)
+}
+
+// Cost of the next baseline trickle upgrade
+func (g *Game) trickleUpgradeCost() float64 {
+	return trickleBaseCost * math.Pow(trickleCostScaling, float64(g.trickleLevel))
+}
+
+// Buy one baseline trickle upgrade
+func (g *Game) buyTrickleUpgrade() bool {
+	cost := g.trickleUpgradeCost()
+	if g.economyFrozen() || !g.canAfford(cost) {
+		return false
+	}
+	g.spend(cost)
+	g.trickleLevel++
+	g.baselineProduction = trickleBaseline(g.trickleLevel)
+	g.calculateManaPerSec()
+	return true
+}
+
+func (g *Game) trickleButtonRect() (x, y, w, h int) {
+	return g.width/2 - trickleButtonWidth/2, g.height - trickleButtonBottom, trickleButtonWidth, trickleButtonHeight
+}
+
+// Handle a click on the trickle upgrade button, reporting whether it was consumed
+func (g *Game) handleTrickleClick(x, y int) bool {
+	if !g.settings.BaselineTrickle {
+		return false
+	}
+	bx, by, bw, bh := g.trickleButtonRect()
+	if x >= bx && x <= bx+bw && y >= by && y <= by+bh {
+		g.buyTrickleUpgrade()
+		return true
+	}
+	return false
+}
+
+func (g *Game) drawTrickleButton(screen *ebiten.Image) {
+	if !g.settings.BaselineTrickle {
+		return
+	}
+
+	x, y, w, h := g.trickleButtonRect()
+	fill := color.RGBA{60, 60, 60, 255}
+	if g.canAfford(g.trickleUpgradeCost()) {
+		fill = color.RGBA{40, 90, 110, 255}
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), fill, false)
+
+	label := fmt.Sprintf("Trickle %.2f/sec -> %.2f (%s)", g.baselineProduction, g.baselineProduction+trickleStep, g.format(g.trickleUpgradeCost()))
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x+10), float64(y+8))
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+	text.Draw(screen, label, g.face(16), op)
+}
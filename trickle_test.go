@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestBaselineTrickleFloor(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		baseline float64
+	}{
+		{"default baseline", true, baseTrickle},
+		{"upgraded baseline", true, trickleBaseline(10)},
+		{"baseline above all production", true, 1e6},
+		{"disabled", false, 1e6},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.settings.BaselineTrickle = tt.enabled
+		g.baselineProduction = tt.baseline
+		g.calculateManaPerSec()
+		raw := softCapBig(g.rawProduction(), g.config.SoftCap).Float64()
+		want := raw
+		if tt.enabled {
+			want = max(raw, tt.baseline)
+		}
+		if g.totalMultiplier != want {
+			t.Errorf("%s: production = %v, want %v", tt.name, g.totalMultiplier, want)
+		}
+		if tt.enabled && g.totalMultiplier < tt.baseline {
+			t.Errorf("%s: production %v dropped below the baseline %v", tt.name, g.totalMultiplier, tt.baseline)
+		}
+		if g.trickleApplied != (tt.enabled && raw < tt.baseline) {
+			t.Errorf("%s: trickleApplied = %v with raw production %v", tt.name, g.trickleApplied, raw)
+		}
+	}
+}
+
+func TestBuyTrickleUpgrade(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.baselineProduction = trickleBaseline(0)
+	cost := g.trickleUpgradeCost()
+	g.setMana(cost)
+	if !g.buyTrickleUpgrade() {
+		t.Fatal("affordable upgrade wasn't bought")
+	}
+	if g.trickleLevel != 1 || g.baselineProduction != trickleBaseline(1) || g.manaValue() != 0 {
+		t.Errorf("level %d, baseline %v, mana %v after the upgrade", g.trickleLevel, g.baselineProduction, g.manaValue())
+	}
+	if g.buyTrickleUpgrade() {
+		t.Error("unaffordable upgrade was bought")
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const undoWindow = 3.0 // Seconds a purchase can be taken back
+
+// purchaseUndo remembers the last generator purchase so a misclick can be reverted
+type purchaseUndo struct {
+	generator int
+	prevLevel int
+	prevCost  float64
+	level     int     // Level right after the purchase, undo is refused once it changed
+	spent     float64 // Mana refunded by the undo
+	remaining float64 // Seconds left to undo, 0 when there's nothing to undo
+}
+
+// Remember a purchase of generator i that started from prevLevel and prevCost
+func (g *Game) recordPurchase(i, prevLevel int, prevCost, spent float64) {
+	g.undo = purchaseUndo{
+		generator: i,
+		prevLevel: prevLevel,
+		prevCost:  prevCost,
+		level:     g.generators[i].level,
+		spent:     spent,
+		remaining: undoWindow,
+	}
+}
+
+// Let the undo window run out
+func (g *Game) updateUndo(dt float64) {
+	g.undo.remaining = max(0, g.undo.remaining-dt)
+}
+
+// Revert the last purchase with Ctrl+Z
+func (g *Game) handleUndoKey() {
+	if ebiten.IsKeyPressed(ebiten.KeyControl) && inpututil.IsKeyJustPressed(ebiten.KeyZ) {
+		g.undoPurchase()
+	}
+}
+
+// Take back the last purchase if it's still within the undo window, refunding its
+// mana and restoring the generator's level and cost
+func (g *Game) undoPurchase() bool {
+	u := g.undo
+	if u.remaining <= 0 || g.economyFrozen() || u.generator >= len(g.generators) {
+		return false
+	}
+	generator := &g.generators[u.generator]
+	// A prestige, retirement or load since the purchase makes it stale
+	if generator.retired || generator.level != u.level {
+		g.undo = purchaseUndo{}
+		return false
+	}
+
+	// The refund can't overflow the storage that production refilled meanwhile
+	refund := NewBigNumber(u.spent)
+	if room := g.manaCap().Add(g.mana.Neg()); refund.Cmp(room) > 0 {
+		refund = room
+	}
+	if refund.sign() > 0 {
+		g.mana = g.mana.Add(refund)
+	}
+	generator.level = u.prevLevel
+	generator.cost = u.prevCost
+	if generator.level == 0 {
+		g.unlockAnimations[u.generator] = 0
+	}
+	g.undo = purchaseUndo{}
+	g.logEvent("undid purchase of %s, back to level %d", generator.name, generator.level)
+
+	g.calculateManaPerSec()
+	return true
+}
+
+// Remind that the last purchase can still be reverted, beside the buy toggle
+func (g *Game) drawUndoHint(screen *ebiten.Image) {
+	if g.undo.remaining <= 0 {
+		return
+	}
+	// A record outliving its generator, e.g. after a config with fewer of them
+	if g.undo.generator >= len(g.generators) {
+		g.undo = purchaseUndo{}
+		return
+	}
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(buyToggleX+buyToggleW+10, float64(g.height-buyToggleBottom)+8)
+	op.ColorScale.ScaleWithColor(color.RGBA{200, 200, 200, 255})
+	text.Draw(screen, fmt.Sprintf("Ctrl+Z: undo %s (%.0fs)", g.generators[g.undo.generator].name, g.undo.remaining), g.face(16), op)
+}
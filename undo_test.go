@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUndoPurchase(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.setMana(1000)
+	cost := g.generators[0].cost
+	if !g.buyGenerator(0) {
+		t.Fatal("purchase failed")
+	}
+	if !g.undoPurchase() {
+		t.Fatal("undo within the window was refused")
+	}
+	if math.Abs(g.manaValue()-1000) > 1e-9 || g.generators[0].level != 0 || g.generators[0].cost != cost {
+		t.Errorf("after undo: mana %v, level %d, cost %v", g.manaValue(), g.generators[0].level, g.generators[0].cost)
+	}
+	if g.undoPurchase() {
+		t.Error("the same purchase was undone twice")
+	}
+}
+
+func TestUndoPurchaseRefused(t *testing.T) {
+	tests := []struct {
+		name  string
+		after func(g *Game)
+	}{
+		{"expired", func(g *Game) { g.updateUndo(undoWindow) }},
+		{"stale after another level", func(g *Game) { g.generators[0].level++ }},
+		{"stale after retirement", func(g *Game) { g.generators[0].retired = true }},
+		{"economy paused", func(g *Game) { g.economyPaused = true }},
+	}
+	for _, tt := range tests {
+		g := newIdleTestGame(t)
+		g.setMana(1000)
+		if !g.buyGenerator(0) {
+			t.Fatal("purchase failed")
+		}
+		tt.after(g)
+		mana, level := g.manaValue(), g.generators[0].level
+		if g.undoPurchase() {
+			t.Errorf("%s: purchase was undone", tt.name)
+		}
+		if g.manaValue() != mana || g.generators[0].level != level {
+			t.Errorf("%s: refused undo changed mana to %v and level to %d", tt.name, g.manaValue(), g.generators[0].level)
+		}
+	}
+}
+
+func TestUndoPurchaseRefundCapped(t *testing.T) {
+	// Production refilled the storage meanwhile, the refund can't overflow it
+	g := newIdleTestGame(t)
+	g.storageLevel = 0
+	g.setMana(baseManaCap)
+	if !g.buyGenerator(0) {
+		t.Fatal("purchase failed")
+	}
+	g.setMana(baseManaCap)
+	if !g.undoPurchase() {
+		t.Fatal("undo was refused")
+	}
+	if g.mana.Cmp(g.manaCap()) != 0 || g.generators[0].level != 0 {
+		t.Errorf("after undo at the cap: mana %v, level %d", g.mana, g.generators[0].level)
+	}
+}
+
+func TestDrawUndoHintStaleGenerator(t *testing.T) {
+	g := newIdleTestGame(t)
+	g.undo = purchaseUndo{generator: len(g.generators), remaining: undoWindow}
+	g.drawUndoHint(nil) // Must return before drawing anything
+	if g.undo.remaining != 0 {
+		t.Errorf("stale undo record kept: %+v", g.undo)
+	}
+}
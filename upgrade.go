@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	upgradeGainStep     = 0.005 // Multiplier gain per rotation added by each upgrade
+	upgradeCostFactor   = 10.0  // First upgrade costs this many times the generator's base cost
+	upgradeCostScaling  = 3.0   // Cost growth per upgrade level
+	upgradeButtonWidth  = 200
+	upgradeButtonHeight = 30
+	upgradeButtonOffset = 95 // Below the top of the generator panel, next to the multiplier line
+)
+
+// Mana needed for the next rotation gain upgrade of generator i
+func (g *Game) upgradeCost(i int) float64 {
+	base := g.config.Generators[i].Cost * upgradeCostFactor
+	return base * math.Pow(upgradeCostScaling, float64(g.generators[i].upgradeLevel))
+}
+
+// Raise how much generator i's multiplier grows per rotation
+func (g *Game) buyUpgrade(i int) bool {
+	generator := &g.generators[i]
+	cost := g.upgradeCost(i)
+	if g.economyFrozen() || !generator.owned() || !g.canAfford(cost) {
+		return false
+	}
+	g.spend(cost)
+	generator.upgradeLevel++
+	generator.multiplierGain = rotationMultiplierGain + upgradeGainStep*float64(generator.upgradeLevel)
+	g.logEvent("upgraded %s to +%.3f per rotation", generator.name, generator.multiplierGain)
+	return true
+}
+
+// Owned generators have an upgrade button, unless their shop row is scrolled out of view
+func (g *Game) upgradeShown(i int) bool {
+	return g.generators[i].owned() && (!g.shopListShown() || g.shopRowVisible(i))
+}
+
+// Upgrade button of generator i, beside its panel on the side facing the center
+func (g *Game) upgradeRect(i int) (x, y, w, h int) {
+	px, py, pw, _ := g.generatorRect(i)
+	// In the shop list it sits in the row, below the buy button
+	if g.shopListShown() {
+		return px + pw - upgradeButtonWidth - shopPadding, py + shopBuyH + 10, upgradeButtonWidth, upgradeButtonHeight
+	}
+	x = px + pw + 10
+	if px > g.width/2 {
+		x = px - 10 - upgradeButtonWidth
+	}
+	return x, py + upgradeButtonOffset, upgradeButtonWidth, upgradeButtonHeight
+}
+
+// Handle a click on an upgrade button, reporting whether it was consumed
+func (g *Game) handleUpgradeClicks(x, y int) bool {
+	for i := range g.generators {
+		if !g.upgradeShown(i) {
+			continue
+		}
+		bx, by, bw, bh := g.upgradeRect(i)
+		if x >= bx && x <= bx+bw && y >= by && y <= by+bh {
+			g.buyUpgrade(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) drawUpgrades(screen *ebiten.Image) {
+	for i := range g.generators {
+		if !g.upgradeShown(i) {
+			continue
+		}
+		x, y, w, h := g.upgradeRect(i)
+		cost := g.upgradeCost(i)
+		fill := color.RGBA{60, 60, 60, 255}
+		if g.canAfford(cost) {
+			fill = color.RGBA{40, 90, 60, 255}
+		}
+		vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), fill, false)
+
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(x)+8, float64(y)+5)
+		op.ColorScale.ScaleWithColor(color.RGBA{255, 255, 255, 255})
+		text.Draw(screen, fmt.Sprintf("+%.3f/rot: %s", upgradeGainStep, g.format(cost)), g.face(16), op)
+	}
+}